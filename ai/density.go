@@ -0,0 +1,126 @@
+// Package ai contains the logic of choosing the cells to fire at, independent of any user interface.
+package ai
+
+import "battleship_client/board"
+
+// Weight by which a possible ship position is multiplied for every already hit cell it covers.
+// Makes the cells next to hits much more likely than the rest of the board, so hit ships are finished first.
+const hitWeight = 30
+
+// Probability of a ship occupying each cell of the board. Cells that were already shot have zero probability.
+type Heatmap [board.Size][board.Size]float64
+
+func (h *Heatmap) At(c board.Coord) float64 {
+	return h[c.X][c.Y]
+}
+
+// Returns the cell with the highest probability. Reports false if no cell can contain a ship.
+func (h *Heatmap) Best() (board.Coord, bool) {
+	best := board.Coord{}
+	found := false
+	for _, c := range board.AllCoords() {
+		if h.At(c) > 0 && (!found || h.At(c) > h.At(best)) {
+			best = c
+			found = true
+		}
+	}
+	return best, found
+}
+
+// Returns the lengths of the ships that are still afloat on the opponent's board.
+func RemainingFleet(view board.Grid) []int {
+	return board.Remaining(view.SunkShips())
+}
+
+// Computes the probability of a ship occupying each cell, given the results of the shots at the opponent's board
+// and the lengths of the ships that are still afloat.
+// Every straight position of every remaining ship that agrees with the shot results is counted,
+// and positions that cover hit cells are weighted higher.
+func Density(view board.Grid, remaining []int) Heatmap {
+	counts := Heatmap{}
+	// Ships of the same length have the same positions, so each length is counted once per ship.
+	for _, length := range remaining {
+		for _, pos := range positions(length) {
+			weight, ok := positionWeight(view, pos)
+			if !ok {
+				continue
+			}
+			for _, c := range pos.ship {
+				if view.At(c) == board.Empty {
+					counts[c.X][c.Y] += weight
+				}
+			}
+		}
+	}
+	total := 0.0
+	for _, c := range board.AllCoords() {
+		total += counts.At(c)
+	}
+	if total == 0 {
+		return counts
+	}
+	for _, c := range board.AllCoords() {
+		counts[c.X][c.Y] /= total
+	}
+	return counts
+}
+
+// Possible position of a ship together with the cells around it.
+type position struct {
+	ship         board.Ship
+	surroundings []board.Coord
+}
+
+// Positions of the ships of every length in the fleet, computed once as they never change.
+var fleetPositions = func() map[int][]position {
+	out := make(map[int][]position)
+	for _, length := range board.Fleet {
+		if _, ok := out[length]; !ok {
+			out[length] = allPositions(length)
+		}
+	}
+	return out
+}()
+
+// Returns the weight of the possible ship position, or false if the position contradicts the shot results:
+// it covers a missed or sunk cell, or touches a hit cell that it does not cover.
+func positionWeight(view board.Grid, pos position) (float64, bool) {
+	weight := 1.0
+	for _, c := range pos.ship {
+		switch view.At(c) {
+		case board.Miss, board.Sunk:
+			return 0, false
+		case board.Hit:
+			weight *= hitWeight
+		}
+	}
+	for _, c := range pos.surroundings {
+		if cell := view.At(c); cell == board.Hit || cell == board.Sunk {
+			return 0, false
+		}
+	}
+	return weight, true
+}
+
+// Returns all the straight positions of a ship of the given length on the board.
+func positions(length int) []position {
+	if pos, ok := fleetPositions[length]; ok {
+		return pos
+	}
+	return allPositions(length)
+}
+
+func allPositions(length int) []position {
+	out := make([]position, 0)
+	for _, start := range board.AllCoords() {
+		for _, vertical := range []bool{false, true} {
+			if vertical && length == 1 {
+				continue
+			}
+			if ship, ok := board.StraightShip(start, length, vertical); ok {
+				out = append(out, position{ship: ship, surroundings: ship.Surroundings()})
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,124 @@
+package ai
+
+import (
+	"battleship_client/board"
+	"math"
+	"slices"
+	"testing"
+)
+
+// Returns the view with the cells set to the given states.
+func testView(cells map[string]board.Cell) board.Grid {
+	view := board.Grid{}
+	for coord, cell := range cells {
+		c, _ := board.ParseCoord(coord)
+		view.Set(c, cell)
+	}
+	return view
+}
+
+func coord(s string) board.Coord {
+	c, _ := board.ParseCoord(s)
+	return c
+}
+
+func TestRemainingFleet(t *testing.T) {
+	tests := []struct {
+		name  string
+		cells map[string]board.Cell
+		want  []int
+	}{
+		{name: "nothing sunk", want: board.Fleet},
+		{name: "hit ship is still afloat", cells: map[string]board.Cell{"A1": board.Hit, "A2": board.Hit}, want: board.Fleet},
+		{
+			name:  "sunk ships are removed",
+			cells: map[string]board.Cell{"A1": board.Sunk, "A2": board.Sunk, "A3": board.Sunk, "A4": board.Sunk, "J10": board.Sunk},
+			want:  []int{3, 3, 2, 2, 2, 1, 1, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RemainingFleet(testView(tt.cells)); !slices.Equal(got, tt.want) {
+				t.Errorf("RemainingFleet() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDensity(t *testing.T) {
+	tests := []struct {
+		name  string
+		cells map[string]board.Cell
+		// Cells that cannot contain a ship.
+		wantZero []string
+		// Pairs of cells where the first is more likely to contain a ship than the second.
+		wantMore [][2]string
+		// Cells one of which is the most likely to contain a ship.
+		wantBest []string
+	}{
+		{
+			name:     "empty board",
+			wantMore: [][2]string{{"E5", "A1"}, {"B2", "A1"}},
+		},
+		{
+			name:     "missed cells",
+			cells:    map[string]board.Cell{"E5": board.Miss, "F5": board.Miss},
+			wantZero: []string{"E5", "F5"},
+		},
+		{
+			name:     "hit cell",
+			cells:    map[string]board.Cell{"E5": board.Hit},
+			wantZero: []string{"E5", "D4", "F6"},
+			wantMore: [][2]string{{"E6", "A1"}, {"D5", "J10"}},
+			wantBest: []string{"D5", "F5", "E4", "E6"},
+		},
+		{
+			name:     "two hits in a row",
+			cells:    map[string]board.Cell{"E5": board.Hit, "E6": board.Hit},
+			wantZero: []string{"D5", "F5", "D6", "F6"},
+			wantBest: []string{"E4", "E7"},
+		},
+		{
+			name:     "sunk ship and its surroundings",
+			cells:    map[string]board.Cell{"A1": board.Sunk, "A2": board.Sunk},
+			wantZero: []string{"A1", "A2", "A3", "B1", "B2", "B3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view := testView(tt.cells)
+			heatmap := Density(view, RemainingFleet(view))
+
+			total := 0.0
+			for _, c := range board.AllCoords() {
+				total += heatmap.At(c)
+			}
+			if math.Abs(total-1) > 1e-9 {
+				t.Errorf("probabilities sum up to %f, want 1", total)
+			}
+			for _, c := range tt.wantZero {
+				if p := heatmap.At(coord(c)); p != 0 {
+					t.Errorf("probability of %s = %f, want 0", c, p)
+				}
+			}
+			for _, pair := range tt.wantMore {
+				if heatmap.At(coord(pair[0])) <= heatmap.At(coord(pair[1])) {
+					t.Errorf("probability of %s = %f, want more than %f of %s",
+						pair[0], heatmap.At(coord(pair[0])), heatmap.At(coord(pair[1])), pair[1])
+				}
+			}
+			if tt.wantBest != nil {
+				if best, ok := heatmap.Best(); !ok || !slices.Contains(tt.wantBest, best.String()) {
+					t.Errorf("Best() = %s, %t, want one of %v", best, ok, tt.wantBest)
+				}
+			}
+		})
+	}
+}
+
+func TestDensityWithoutShips(t *testing.T) {
+	heatmap := Density(board.Grid{}, nil)
+	if best, ok := heatmap.Best(); ok {
+		t.Errorf("Best() without remaining ships = %s, want none", best)
+	}
+}
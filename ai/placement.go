@@ -0,0 +1,72 @@
+package ai
+
+import (
+	"battleship_client/board"
+	"fmt"
+	"math/rand"
+)
+
+// Names of the available placement strategies, accepted by `NewPlacement`.
+const (
+	RandomPlacement = "random"
+	EdgePlacement   = "edge"
+)
+
+// Number of random fleets out of which `Edge` chooses the one closest to the edges.
+const edgeCandidates = 50
+
+// Places the own fleet on the board.
+type Placement interface {
+	// Returns a valid fleet according to `board.ValidateFleet`.
+	Place() []board.Ship
+}
+
+// Returns the names of all the placement strategies.
+func PlacementNames() []string {
+	return []string{RandomPlacement, EdgePlacement}
+}
+
+// Creates the placement strategy with the given name.
+func NewPlacement(name string, rng *rand.Rand) (Placement, error) {
+	switch name {
+	case RandomPlacement:
+		return RandomFleet{rng: rng}, nil
+	case EdgePlacement:
+		return Edge{rng: rng}, nil
+	}
+	return nil, fmt.Errorf("unknown placement %q", name)
+}
+
+// Places the fleet at random, see `board.RandomFleet`.
+type RandomFleet struct {
+	rng *rand.Rand
+}
+
+func (p RandomFleet) Place() []board.Ship {
+	return board.RandomFleet(p.rng)
+}
+
+// Places the fleet at random, preferring the cells on the edges of the board,
+// where density based targeting expects ships the least.
+type Edge struct {
+	rng *rand.Rand
+}
+
+func (p Edge) Place() []board.Ship {
+	var best []board.Ship
+	bestScore := -1
+	for i := 0; i < edgeCandidates; i++ {
+		ships := board.RandomFleet(p.rng)
+		score := 0
+		for _, c := range board.Cells(ships) {
+			if c.X == 0 || c.Y == 0 || c.X == board.Size-1 || c.Y == board.Size-1 {
+				score++
+			}
+		}
+		if score > bestScore {
+			best = ships
+			bestScore = score
+		}
+	}
+	return best
+}
@@ -0,0 +1,23 @@
+package ai
+
+import (
+	"battleship_client/board"
+	"math/rand"
+	"testing"
+)
+
+func TestPlacementsAreValid(t *testing.T) {
+	for _, name := range PlacementNames() {
+		t.Run(name, func(t *testing.T) {
+			placement, err := NewPlacement(name, rand.New(rand.NewSource(1)))
+			if err != nil {
+				t.Fatalf("NewPlacement(%q) error: %s", name, err)
+			}
+			for i := 0; i < 10; i++ {
+				if err := board.ValidateFleet(board.Cells(placement.Place())); err != nil {
+					t.Fatalf("Place() returned an invalid fleet: %s", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package ai
+
+import (
+	"battleship_client/board"
+	"errors"
+	"fmt"
+	"math/rand"
+)
+
+// Names of the available strategies, accepted by `NewStrategy`.
+const (
+	RandomStrategy  = "random"
+	HuntStrategy    = "hunt"
+	DensityStrategy = "density"
+)
+
+// Returned when there is no cell left to fire at.
+var ErrNoTarget = errors.New("no cell left to fire at")
+
+// Chooses the cells to fire at on the opponent's board.
+type Strategy interface {
+	// Returns the cell to fire at next, given the results of the shots fired so far.
+	// The returned cell is always empty on the given board.
+	Next(view board.Grid) (board.Coord, error)
+}
+
+// Returns the names of all the strategies.
+func StrategyNames() []string {
+	return []string{RandomStrategy, HuntStrategy, DensityStrategy}
+}
+
+// Creates the strategy with the given name. The generator is used to break ties and to pick random cells.
+func NewStrategy(name string, rng *rand.Rand) (Strategy, error) {
+	switch name {
+	case RandomStrategy:
+		return Random{rng: rng}, nil
+	case HuntStrategy:
+		return HuntTarget{rng: rng}, nil
+	case DensityStrategy:
+		return ProbabilityDensity{rng: rng}, nil
+	}
+	return nil, fmt.Errorf("unknown strategy %q", name)
+}
+
+// Fires at random cells that were not shot yet.
+type Random struct {
+	rng *rand.Rand
+}
+
+func (s Random) Next(view board.Grid) (board.Coord, error) {
+	return pick(s.rng, view.Find(board.Empty))
+}
+
+// Fires at random cells of a checkerboard pattern until it hits a ship, then fires around the hits until the ship is sunk.
+type HuntTarget struct {
+	rng *rand.Rand
+}
+
+func (s HuntTarget) Next(view board.Grid) (board.Coord, error) {
+	if targets := targetCells(view); len(targets) > 0 {
+		return pick(s.rng, targets)
+	}
+	// Every ship longer than one cell covers at least one cell of the pattern.
+	parity := make([]board.Coord, 0)
+	for _, c := range view.Find(board.Empty) {
+		if (c.X+c.Y)%2 == 0 {
+			parity = append(parity, c)
+		}
+	}
+	if len(parity) > 0 {
+		return pick(s.rng, parity)
+	}
+	return pick(s.rng, view.Find(board.Empty))
+}
+
+// Returns the empty cells that can continue the hit ships.
+// If a ship was hit more than once, only the cells in line with the hits are returned.
+func targetCells(view board.Grid) []board.Coord {
+	out := make([]board.Coord, 0)
+	for _, c := range view.Find(board.Hit) {
+		horizontal, vertical := false, false
+		for _, near := range c.Adjacent() {
+			if view.At(near) == board.Hit {
+				horizontal = horizontal || near.Y == c.Y
+				vertical = vertical || near.X == c.X
+			}
+		}
+		for _, near := range c.Adjacent() {
+			if view.At(near) != board.Empty {
+				continue
+			}
+			if (horizontal && near.Y != c.Y) || (vertical && near.X != c.X) {
+				continue
+			}
+			out = append(out, near)
+		}
+	}
+	return out
+}
+
+// Fires at the cell most likely to contain a ship according to `Density`.
+type ProbabilityDensity struct {
+	rng *rand.Rand
+}
+
+func (s ProbabilityDensity) Next(view board.Grid) (board.Coord, error) {
+	heatmap := Density(view, RemainingFleet(view))
+	best := make([]board.Coord, 0)
+	highest := 0.0
+	for _, c := range board.AllCoords() {
+		switch p := heatmap.At(c); {
+		case p > highest:
+			highest = p
+			best = append(best[:0], c)
+		case p == highest && p > 0:
+			best = append(best, c)
+		}
+	}
+	if len(best) == 0 {
+		// Happens only if the shot results contradict the fleet, so any empty cell is as good as another.
+		return pick(s.rng, view.Find(board.Empty))
+	}
+	return pick(s.rng, best)
+}
+
+func pick(rng *rand.Rand, coords []board.Coord) (board.Coord, error) {
+	if len(coords) == 0 {
+		return board.Coord{}, ErrNoTarget
+	}
+	return coords[rng.Intn(len(coords))], nil
+}
@@ -0,0 +1,107 @@
+package ai
+
+import (
+	"battleship_client/board"
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// Fires the shots of the strategy at the fleet until it is sunk, and returns the number of shots.
+func playOut(t *testing.T, strategy Strategy, ships []board.Ship) int {
+	t.Helper()
+	view := board.Grid{}
+	cells := board.Cells(ships)
+	hits := 0
+	for shots := 1; shots <= board.Size*board.Size; shots++ {
+		c, err := strategy.Next(view)
+		if err != nil {
+			t.Fatalf("Next() error after %d shots: %s", shots-1, err)
+		}
+		if view.At(c) != board.Empty {
+			t.Fatalf("Next() = %s, which is not empty on the view", c)
+		}
+		result := board.ResultMiss
+		if slices.Contains(cells, c) {
+			hits++
+			result = board.ResultHit
+			for _, ship := range ships {
+				if slices.Contains(ship, c) && !slices.ContainsFunc(ship, func(s board.Coord) bool { return s != c && view.At(s) != board.Hit }) {
+					result = board.ResultSunk
+				}
+			}
+		}
+		if _, err := view.ApplyResult(c, result); err != nil {
+			t.Fatalf("ApplyResult(%s, %s) error: %s", c, result, err)
+		}
+		if hits == board.FleetCells {
+			return shots
+		}
+	}
+	t.Fatalf("fleet was not sunk after shooting the whole board")
+	return 0
+}
+
+func TestStrategiesSinkFleet(t *testing.T) {
+	for _, name := range StrategyNames() {
+		t.Run(name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(1))
+			for game := 0; game < 10; game++ {
+				strategy, err := NewStrategy(name, rng)
+				if err != nil {
+					t.Fatalf("NewStrategy(%q) error: %s", name, err)
+				}
+				playOut(t, strategy, board.RandomFleet(rng))
+			}
+		})
+	}
+}
+
+func TestNewStrategyUnknown(t *testing.T) {
+	if _, err := NewStrategy("sniper", nil); err == nil {
+		t.Errorf("NewStrategy(sniper) error = nil, want an error")
+	}
+}
+
+func TestNextWithoutTarget(t *testing.T) {
+	view := board.Grid{}
+	for _, c := range board.AllCoords() {
+		view.Set(c, board.Miss)
+	}
+	for _, name := range StrategyNames() {
+		strategy, _ := NewStrategy(name, rand.New(rand.NewSource(1)))
+		if _, err := strategy.Next(view); !errors.Is(err, ErrNoTarget) {
+			t.Errorf("%s: Next() on a full board error = %v, want %v", name, err, ErrNoTarget)
+		}
+	}
+}
+
+func TestHuntTargetFollowsHits(t *testing.T) {
+	tests := []struct {
+		name string
+		hits []string
+		want []string
+	}{
+		{"single hit", []string{"E5"}, []string{"D5", "F5", "E4", "E6"}},
+		{"vertical hits", []string{"E5", "E6"}, []string{"E4", "E7"}},
+		{"horizontal hits", []string{"E5", "F5"}, []string{"D5", "G5"}},
+		{"hits at the edge", []string{"A1", "A2"}, []string{"A3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view := board.Grid{}
+			for _, hit := range tt.hits {
+				c, _ := board.ParseCoord(hit)
+				view.Set(c, board.Hit)
+			}
+			strategy := HuntTarget{rng: rand.New(rand.NewSource(1))}
+			for i := 0; i < 20; i++ {
+				c, err := strategy.Next(view)
+				if err != nil || !slices.Contains(tt.want, c.String()) {
+					t.Fatalf("Next() = %s, %v, want one of %v", c, err, tt.want)
+				}
+			}
+		})
+	}
+}
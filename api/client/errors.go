@@ -0,0 +1,149 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Errors describing the kind of failure reported by the server.
+// They can be matched with `errors.Is` against any error returned by the client.
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	// Returned by the requests of the game in progress when the server no longer has the game of the token.
+	ErrGameNotFound = errors.New("game not found")
+	// Returned by `PlayerStats` when the server has no statistics of the player.
+	ErrPlayerNotFound = errors.New("player not found")
+	ErrRateLimited    = errors.New("rate limited")
+	ErrInvalidCoord   = errors.New("invalid coordinate")
+	ErrNotYourTurn    = errors.New("not your turn")
+	ErrBadRequest     = errors.New("bad request")
+	// Returned when the endpoint does not give 404 a more specific meaning.
+	ErrNotFound = errors.New("not found")
+	ErrServer   = errors.New("server error")
+)
+
+// Error returned when the server responds with a status other than 200 OK.
+type ResponseError struct {
+	StatusCode int
+	// Message sent by the server, or the HTTP status if the server did not send any.
+	Message string
+	// Time the server asked to wait before the next request. Set only for rate limited responses.
+	RetryAfter time.Duration
+	kind       error
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("response error: %s", e.Message)
+}
+
+// Returns one of the sentinel errors describing the failure, so the error can be matched with `errors.Is`.
+func (e *ResponseError) Unwrap() error {
+	return e.kind
+}
+
+// Returns nil if the response status is 200 OK.
+// Otherwise reads the message from the response body, closes it and returns a `*ResponseError`.
+// The statuses whose meaning depends on the endpoint are mapped with `endpointKind`, which can be nil.
+func checkResponse(res *http.Response, endpointKind kindFunc) error {
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+	defer res.Body.Close()
+	body, _ := io.ReadAll(res.Body)
+	mes := ""
+	if jsonBody, err := unmarshalFromBytes[map[string]any](body); err == nil {
+		if m, ok := jsonBody["message"].(string); ok {
+			mes = m
+		}
+	}
+	if mes == "" {
+		mes = res.Status
+	}
+	resErr := &ResponseError{
+		StatusCode: res.StatusCode,
+		Message:    mes,
+		kind:       errorKind(res.StatusCode, mes, endpointKind),
+	}
+	if res.StatusCode == http.StatusTooManyRequests {
+		resErr.RetryAfter = parseRetryAfter(res.Header.Get("Retry-After"))
+	}
+	return resErr
+}
+
+// Maps the status code and the message of a response to the sentinel error specific to the endpoint that sent it.
+// Returns nil for the statuses that mean the same for every endpoint.
+type kindFunc func(statusCode int, message string) error
+
+// Maps the responses to the requests of the game in progress, in which 404 means the game of the token is gone.
+func gameKind(statusCode int, _ string) error {
+	if statusCode == http.StatusNotFound {
+		return ErrGameNotFound
+	}
+	return nil
+}
+
+// Maps the responses to the shots. The server uses 400 for every invalid shot, so the message has to be checked.
+func fireKind(statusCode int, message string) error {
+	if statusCode != http.StatusBadRequest {
+		return gameKind(statusCode, message)
+	}
+	mes := strings.ToLower(message)
+	switch {
+	case strings.Contains(mes, "turn"):
+		return ErrNotYourTurn
+	case strings.Contains(mes, "coord"):
+		return ErrInvalidCoord
+	}
+	return nil
+}
+
+// Maps the responses to the statistics of a player, in which 404 means the player has not played yet.
+func playerStatsKind(statusCode int, _ string) error {
+	if statusCode == http.StatusNotFound {
+		return ErrPlayerNotFound
+	}
+	return nil
+}
+
+// Maps the status code and the message of the response to one of the sentinel errors.
+// The mapping of the endpoint takes precedence over the one shared by all the endpoints.
+func errorKind(statusCode int, message string, endpointKind kindFunc) error {
+	if endpointKind != nil {
+		if kind := endpointKind(statusCode, message); kind != nil {
+			return kind
+		}
+	}
+	switch {
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return ErrUnauthorized
+	case statusCode == http.StatusNotFound:
+		return ErrNotFound
+	case statusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case statusCode >= 500:
+		return ErrServer
+	}
+	return ErrBadRequest
+}
+
+// Parses the value of the Retry-After header given either in seconds or as an HTTP date.
+// Returns 0 if the value is missing or invalid.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if d := time.Until(date); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
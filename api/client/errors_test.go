@@ -0,0 +1,37 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestErrorKind(t *testing.T) {
+	tests := []struct {
+		name         string
+		statusCode   int
+		message      string
+		endpointKind kindFunc
+		want         error
+	}{
+		{"game not found", http.StatusNotFound, "game not found", gameKind, ErrGameNotFound},
+		{"shot of a missing game", http.StatusNotFound, "game not found", fireKind, ErrGameNotFound},
+		{"player not found", http.StatusNotFound, "no stats", playerStatsKind, ErrPlayerNotFound},
+		{"not found without endpoint mapping", http.StatusNotFound, "not found", nil, ErrNotFound},
+		{"not your turn", http.StatusBadRequest, "It's not your turn", fireKind, ErrNotYourTurn},
+		{"invalid coord", http.StatusBadRequest, "invalid coord", fireKind, ErrInvalidCoord},
+		{"other invalid shot", http.StatusBadRequest, "bad body", fireKind, ErrBadRequest},
+		{"coord outside of fire", http.StatusBadRequest, "invalid coords of ships", nil, ErrBadRequest},
+		{"unauthorized", http.StatusUnauthorized, "", gameKind, ErrUnauthorized},
+		{"forbidden", http.StatusForbidden, "", nil, ErrUnauthorized},
+		{"rate limited", http.StatusTooManyRequests, "", fireKind, ErrRateLimited},
+		{"server error", http.StatusServiceUnavailable, "", playerStatsKind, ErrServer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorKind(tt.statusCode, tt.message, tt.endpointKind); !errors.Is(got, tt.want) {
+				t.Errorf("errorKind(%d, %q) = %v, want %v", tt.statusCode, tt.message, got, tt.want)
+			}
+		})
+	}
+}
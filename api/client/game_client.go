@@ -1,25 +1,28 @@
 package client
 
 import (
+	"battleship_client/board"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
-	"time"
+	"strings"
 
 	"github.com/hashicorp/go-retryablehttp"
 )
 
 const (
-	serverApi    = "https://go-pjatk-server.fly.dev/api"
 	tokenKey     = "X-Auth-Token"
 	unmarshalErr = "failed to unmarshal from ReadCloser"
 )
 
 type GameClient struct {
-	client *retryablehttp.Client
-	Token  string
+	client    *retryablehttp.Client
+	serverApi string
+	userAgent string
+	Token     string
 }
 
 type GameSettings struct {
@@ -57,73 +60,94 @@ type LobbyGame struct {
 	Nick   string `json:"nick"`
 }
 
-func newRetryableClient() (c *retryablehttp.Client) {
-	c = retryablehttp.NewClient()
-	c.RetryMax = 5
-	c.RetryWaitMin = time.Second
-	c.RetryWaitMax = time.Second * 3
-	c.Logger = nil
-	return
+// Creates a client for the server described by the options. The client has no token until the game is initialised.
+func NewGameClient(opts Options) GameClient {
+	opts = opts.withDefaults()
+	return GameClient{
+		client:    newRetryableClient(opts),
+		serverApi: strings.TrimSuffix(opts.BaseURL, "/"),
+		userAgent: opts.UserAgent,
+	}
+}
+
+// Creates a new game on the server with the given settings and returns a client holding the game token.
+func InitGame(settings GameSettings, opts Options) (GameClient, error) {
+	return InitGameContext(context.Background(), settings, opts)
 }
 
-func InitGame(settings GameSettings) (GameClient, error) {
+// Works like `InitGame`, but the request is cancelled when the context is done.
+// The settings are validated before sending. Coordinates of the ships are not validated if they are empty and the server is to place the ships.
+func InitGameContext(ctx context.Context, settings GameSettings, opts Options) (GameClient, error) {
+	game := NewGameClient(opts)
+	if err := settings.Validate(); err != nil {
+		return game, fmt.Errorf("invalid game settings: %w", err)
+	}
+	if len(settings.Coords) > 0 {
+		if err := board.ValidateCoords(settings.Coords); err != nil {
+			return game, fmt.Errorf("invalid ship coords: %w", err)
+		}
+	}
 	requestBody, err := json.Marshal(settings)
-	game := GameClient{client: newRetryableClient()}
 	if err != nil {
 		return game, fmt.Errorf("failed to marshal settings to json: %w", err)
 	}
 	r := bytes.NewReader(requestBody)
-	res, err := game.client.Post(serverApi+"/game", "application/json", r)
+	res, err := game.sendRequest(ctx, http.MethodPost, "/game", r)
 	if err != nil {
 		return game, fmt.Errorf("failed to send POST request: %w", err)
 	}
-	if res.StatusCode != 200 {
-		responseBody, err := unmarshalFromReadCloser[map[string]any](&res.Body)
-		if err != nil {
-			return game, fmt.Errorf("%s: %w", unmarshalErr, err)
-		}
-		mes, ok := responseBody["message"]
-		if !ok {
-			mes = res.Status
-		}
-		return game, fmt.Errorf("response error: %s", mes)
+	if err := checkResponse(res, nil); err != nil {
+		return game, err
 	}
+	res.Body.Close()
 	game.Token = res.Header.Get(tokenKey)
 	return game, nil
 }
 
 func (g GameClient) Board() ([]string, error) {
-	res, err := g.sendRequest(http.MethodGet, "/game/board", nil)
+	return g.BoardContext(context.Background())
+}
+
+func (g GameClient) BoardContext(ctx context.Context) ([]string, error) {
+	res, err := g.sendRequest(ctx, http.MethodGet, "/game/board", nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send GET request: %w", err)
 	}
+	if err := checkResponse(res, gameKind); err != nil {
+		return nil, err
+	}
 	boardRes, err := unmarshalFromReadCloser[boardResponse](&res.Body)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", unmarshalErr, err)
 	}
-	if res.StatusCode == 401 || res.StatusCode == 403 {
-		return nil, fmt.Errorf("response error: %s", boardRes.Message)
-	}
-	return boardRes.Board, err
+	return boardRes.Board, nil
 }
 
 func (g GameClient) Status() (StatusResponse, error) {
+	return g.StatusContext(context.Background())
+}
+
+func (g GameClient) StatusContext(ctx context.Context) (StatusResponse, error) {
 	statusRes := StatusResponse{}
-	res, err := g.sendRequest(http.MethodGet, "/game", nil)
+	res, err := g.sendRequest(ctx, http.MethodGet, "/game", nil)
 	if err != nil {
 		return statusRes, fmt.Errorf("failed to send GET request: %w", err)
 	}
+	if err := checkResponse(res, gameKind); err != nil {
+		return statusRes, err
+	}
 	statusRes, err = unmarshalFromReadCloser[StatusResponse](&res.Body)
 	if err != nil {
 		return statusRes, fmt.Errorf("%s: %w", unmarshalErr, err)
 	}
-	if res.StatusCode != 200 {
-		return statusRes, fmt.Errorf("response error: %s", statusRes.Message)
-	}
 	return statusRes, nil
 }
 
 func (g GameClient) Fire(coord string) (string, error) {
+	return g.FireContext(context.Background(), coord)
+}
+
+func (g GameClient) FireContext(ctx context.Context, coord string) (string, error) {
 	coords := make(map[string]string)
 	coords["coord"] = coord
 	reqBody, err := json.Marshal(coords)
@@ -131,22 +155,17 @@ func (g GameClient) Fire(coord string) (string, error) {
 		return "", fmt.Errorf("failed to marshal coord to json: %w", err)
 	}
 	r := bytes.NewReader(reqBody)
-	res, err := g.sendRequest(http.MethodPost, "/game/fire", r)
+	res, err := g.sendRequest(ctx, http.MethodPost, "/game/fire", r)
 	if err != nil {
-		return "", fmt.Errorf("failed to send GET request: %w", err)
+		return "", fmt.Errorf("failed to send POST request: %w", err)
+	}
+	if err := checkResponse(res, fireKind); err != nil {
+		return "", err
 	}
 	jsonBody, err := unmarshalFromReadCloser[map[string]string](&res.Body)
 	if err != nil {
 		return "", fmt.Errorf("%s: %w", unmarshalErr, err)
 	}
-
-	if sc := res.StatusCode; sc == 400 || sc == 401 || sc == 403 || sc == 429 {
-		mes, ok := jsonBody["message"]
-		if !ok {
-			mes = res.Status
-		}
-		return "", fmt.Errorf("response error: %s", mes)
-	}
 	result, ok := jsonBody["result"]
 	if !ok {
 		return "", fmt.Errorf("result not found")
@@ -155,27 +174,38 @@ func (g GameClient) Fire(coord string) (string, error) {
 }
 
 func (g GameClient) PlayerDescriptions() (DescriptionResponse, error) {
+	return g.PlayerDescriptionsContext(context.Background())
+}
+
+func (g GameClient) PlayerDescriptionsContext(ctx context.Context) (DescriptionResponse, error) {
 	descriptionRes := DescriptionResponse{}
-	res, err := g.sendRequest(http.MethodGet, "/game/desc", nil)
+	res, err := g.sendRequest(ctx, http.MethodGet, "/game/desc", nil)
 	if err != nil {
 		return descriptionRes, fmt.Errorf("failed to send GET request: %w", err)
 	}
+	if err := checkResponse(res, gameKind); err != nil {
+		return descriptionRes, err
+	}
 	descriptionRes, err = unmarshalFromReadCloser[DescriptionResponse](&res.Body)
 	if err != nil {
 		return descriptionRes, fmt.Errorf("%s: %w", unmarshalErr, err)
 	}
-	if sc := res.StatusCode; sc == 401 || sc == 404 || sc == 429 {
-		return descriptionRes, fmt.Errorf("response error: %s", descriptionRes.Message)
-	}
 	return descriptionRes, nil
 }
 
-func Lobby() ([]LobbyGame, error) {
-	c := newRetryableClient()
-	res, err := c.Get(serverApi + "/lobby")
+func Lobby(opts Options) ([]LobbyGame, error) {
+	return LobbyContext(context.Background(), opts)
+}
+
+func LobbyContext(ctx context.Context, opts Options) ([]LobbyGame, error) {
+	c := NewGameClient(opts)
+	res, err := c.sendRequest(ctx, http.MethodGet, "/lobby", nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send Lobby GET request: %w", err)
 	}
+	if err := checkResponse(res, nil); err != nil {
+		return nil, err
+	}
 	games, err := unmarshalFromReadCloser[[]LobbyGame](&res.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal Lobby GET response: %w", err)
@@ -184,51 +214,51 @@ func Lobby() ([]LobbyGame, error) {
 }
 
 func (g GameClient) Refresh() error {
-	res, err := g.sendRequest(http.MethodGet, "/game/refresh", nil)
+	return g.RefreshContext(context.Background())
+}
+
+func (g GameClient) RefreshContext(ctx context.Context) error {
+	res, err := g.sendRequest(ctx, http.MethodGet, "/game/refresh", nil)
 	if err != nil {
 		return fmt.Errorf("failed to send Refresh GET request: %w", err)
 	}
-
-	if res.StatusCode == 200 {
-		return nil
-	}
-	body, err := unmarshalFromReadCloser[map[string]string](&res.Body)
-	if err != nil {
-		return fmt.Errorf("failed to umarshal Refresh response: %w", err)
+	if err := checkResponse(res, gameKind); err != nil {
+		return err
 	}
-	mes, ok := body["message"]
-	if !ok {
-		mes = res.Status
-	}
-	return fmt.Errorf("response error: %s", mes)
+	res.Body.Close()
+	return nil
 }
 
 func (g GameClient) Abandon() error {
-	res, err := g.sendRequest(http.MethodDelete, "/game/abandon", nil)
-	if err != nil {
-		return fmt.Errorf("failed to send Abandon DELETE request: %w", err)
-	}
+	return g.AbandonContext(context.Background())
+}
 
-	if res.StatusCode == 200 {
-		return nil
-	}
-	body, err := unmarshalFromReadCloser[map[string]string](&res.Body)
+func (g GameClient) AbandonContext(ctx context.Context) error {
+	res, err := g.sendRequest(ctx, http.MethodDelete, "/game/abandon", nil)
 	if err != nil {
-		return fmt.Errorf("failed to umarshal Refresh response: %w", err)
+		return fmt.Errorf("failed to send Abandon DELETE request: %w", err)
 	}
-	mes, ok := body["message"]
-	if !ok {
-		mes = res.Status
+	if err := checkResponse(res, gameKind); err != nil {
+		return err
 	}
-	return fmt.Errorf("response error: %s", mes)
+	res.Body.Close()
+	return nil
 }
 
-func (g GameClient) sendRequest(method string, path string, body io.Reader) (*http.Response, error) {
-	req, err := retryablehttp.NewRequest(method, fmt.Sprintf("%s%s", serverApi, path), body)
+func (g GameClient) sendRequest(ctx context.Context, method string, path string, body io.Reader) (*http.Response, error) {
+	req, err := retryablehttp.NewRequestWithContext(ctx, method, fmt.Sprintf("%s%s", g.serverApi, path), body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create new http request: %w", err)
 	}
-	req.Header.Add(tokenKey, g.Token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if g.userAgent != "" {
+		req.Header.Set("User-Agent", g.userAgent)
+	}
+	if g.Token != "" {
+		req.Header.Add(tokenKey, g.Token)
+	}
 	res, err := g.client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send http request: %w", err)
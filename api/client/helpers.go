@@ -8,12 +8,16 @@ import (
 
 func unmarshalFromReadCloser[T any](rc *io.ReadCloser) (T, error) {
 	defer (*rc).Close()
-	t := new(T)
 	bytes, err := io.ReadAll(*rc)
 	if err != nil {
-		return *t, fmt.Errorf("failed to read from Reader: %w", err)
+		return *new(T), fmt.Errorf("failed to read from Reader: %w", err)
 	}
-	err = json.Unmarshal(bytes, t)
+	return unmarshalFromBytes[T](bytes)
+}
+
+func unmarshalFromBytes[T any](bytes []byte) (T, error) {
+	t := new(T)
+	err := json.Unmarshal(bytes, t)
 	if err != nil {
 		err = fmt.Errorf("failed to map value from JSON: %w", err)
 	}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+const (
+	// Address of the public game server used when no other endpoint is configured.
+	DefaultServerURL = "https://go-pjatk-server.fly.dev/api"
+	// Environment variable that can hold the address of the game server.
+	ServerEnv        = "BATTLESHIP_SERVER"
+	defaultUserAgent = "battleship_client"
+	// Value of `Options.RetryMax` that stands for the default number of retries.
+	DefaultRetries = -1
+)
+
+// Describes how the client connects to the game server.
+// Zero values are replaced with the defaults from `DefaultOptions`, except `RetryMax`, for which 0 disables retries.
+type Options struct {
+	// Base URL of the server API, e.g. "http://localhost:8080/api".
+	BaseURL string
+	// Timeout of a single HTTP request.
+	Timeout time.Duration
+	// Maximum number of retries of a failed request. `DefaultRetries`, or any other negative value, uses the default.
+	RetryMax int
+	// Minimum and maximum time to wait between retries.
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+	// Value of the User-Agent header sent with every request.
+	UserAgent string
+}
+
+func DefaultOptions() Options {
+	return Options{
+		BaseURL:      DefaultServerURL,
+		Timeout:      time.Second * 10,
+		RetryMax:     5,
+		RetryWaitMin: time.Second,
+		RetryWaitMax: time.Second * 3,
+		UserAgent:    defaultUserAgent,
+	}
+}
+
+// Returns a copy of the options with zero values, and a negative `RetryMax`, replaced by the defaults.
+func (o Options) withDefaults() Options {
+	def := DefaultOptions()
+	if o.BaseURL == "" {
+		o.BaseURL = def.BaseURL
+	}
+	if o.Timeout == 0 {
+		o.Timeout = def.Timeout
+	}
+	if o.RetryMax < 0 {
+		o.RetryMax = def.RetryMax
+	}
+	if o.RetryWaitMin == 0 {
+		o.RetryWaitMin = def.RetryWaitMin
+	}
+	if o.RetryWaitMax == 0 {
+		o.RetryWaitMax = def.RetryWaitMax
+	}
+	if o.UserAgent == "" {
+		o.UserAgent = def.UserAgent
+	}
+	return o
+}
+
+func newRetryableClient(opts Options) (c *retryablehttp.Client) {
+	c = retryablehttp.NewClient()
+	c.RetryMax = opts.RetryMax
+	c.RetryWaitMin = opts.RetryWaitMin
+	c.RetryWaitMax = opts.RetryWaitMax
+	c.HTTPClient.Timeout = opts.Timeout
+	c.Logger = nil
+	// Return the last response instead of a generic error when retries run out,
+	// so the status of the response can be turned into a typed error.
+	c.ErrorHandler = retryablehttp.PassthroughErrorHandler
+	return
+}
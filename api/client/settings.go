@@ -0,0 +1,34 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// Limits of the nick and the description accepted by the server.
+// An empty nick is allowed, the server then assigns a random one.
+const (
+	MinNickLength        = 2
+	MaxNickLength        = 10
+	MaxDescriptionLength = 200
+)
+
+// Checks the nick and the description against the limits of the server.
+func (s GameSettings) Validate() error {
+	return errors.Join(ValidateNick(s.Nick), ValidateDescription(s.Description))
+}
+
+func ValidateNick(nick string) error {
+	if n := utf8.RuneCountInString(nick); n != 0 && (n < MinNickLength || n > MaxNickLength) {
+		return fmt.Errorf("nick must have from %d to %d characters", MinNickLength, MaxNickLength)
+	}
+	return nil
+}
+
+func ValidateDescription(desc string) error {
+	if utf8.RuneCountInString(desc) > MaxDescriptionLength {
+		return fmt.Errorf("description must have at most %d characters", MaxDescriptionLength)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateNick(t *testing.T) {
+	tests := []struct {
+		nick    string
+		wantErr bool
+	}{
+		{nick: ""},
+		{nick: "a", wantErr: true},
+		{nick: "al"},
+		{nick: strings.Repeat("a", MaxNickLength)},
+		{nick: strings.Repeat("a", MaxNickLength+1), wantErr: true},
+		// The limits count characters, not bytes.
+		{nick: strings.Repeat("ż", MaxNickLength)},
+		{nick: "ż", wantErr: true},
+	}
+	for _, tt := range tests {
+		if err := ValidateNick(tt.nick); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateNick(%q) error = %v, want error %t", tt.nick, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidateDescription(t *testing.T) {
+	tests := []struct {
+		desc    string
+		wantErr bool
+	}{
+		{desc: ""},
+		{desc: strings.Repeat("a", MaxDescriptionLength)},
+		{desc: strings.Repeat("a", MaxDescriptionLength+1), wantErr: true},
+		{desc: strings.Repeat("ż", MaxDescriptionLength)},
+		{desc: strings.Repeat("ż", MaxDescriptionLength+1), wantErr: true},
+	}
+	for _, tt := range tests {
+		if err := ValidateDescription(tt.desc); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateDescription() of %d characters error = %v, want error %t", len([]rune(tt.desc)), err, tt.wantErr)
+		}
+	}
+}
+
+func TestGameSettingsValidate(t *testing.T) {
+	s := GameSettings{Nick: "a", Description: strings.Repeat("a", MaxDescriptionLength+1)}
+	err := s.Validate()
+	if err == nil || !strings.Contains(err.Error(), "nick") || !strings.Contains(err.Error(), "description") {
+		t.Errorf("Validate() error = %v, want the errors of the nick and the description", err)
+	}
+	if err := (GameSettings{Nick: "alice", Description: "hi"}).Validate(); err != nil {
+		t.Errorf("Validate() of valid settings error: %s", err)
+	}
+}
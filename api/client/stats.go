@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type PlayerStatsResponse struct {
+	Games  int    `json:"games"`
+	Nick   string `json:"nick"`
+	Points int    `json:"points"`
+	Rank   int    `json:"rank"`
+	Wins   int    `json:"wins"`
+}
+
+type statsResponse struct {
+	Stats []PlayerStatsResponse `json:"stats"`
+}
+
+type playerStatsResponse struct {
+	Stats PlayerStatsResponse `json:"stats"`
+}
+
+// Returns the statistics of the best players, ordered by their rank. Does not require a game token.
+func (g GameClient) Stats() ([]PlayerStatsResponse, error) {
+	return g.StatsContext(context.Background())
+}
+
+func (g GameClient) StatsContext(ctx context.Context) ([]PlayerStatsResponse, error) {
+	res, err := g.sendRequest(ctx, http.MethodGet, "/stats", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send Stats GET request: %w", err)
+	}
+	if err := checkResponse(res, nil); err != nil {
+		return nil, err
+	}
+	statsRes, err := unmarshalFromReadCloser[statsResponse](&res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", unmarshalErr, err)
+	}
+	return statsRes.Stats, nil
+}
+
+// Returns the statistics of the player with the given nick. Does not require a game token.
+func (g GameClient) PlayerStats(nick string) (PlayerStatsResponse, error) {
+	return g.PlayerStatsContext(context.Background(), nick)
+}
+
+func (g GameClient) PlayerStatsContext(ctx context.Context, nick string) (PlayerStatsResponse, error) {
+	res, err := g.sendRequest(ctx, http.MethodGet, "/stats/"+url.PathEscape(nick), nil)
+	if err != nil {
+		return PlayerStatsResponse{}, fmt.Errorf("failed to send Player Stats GET request: %w", err)
+	}
+	if err := checkResponse(res, playerStatsKind); err != nil {
+		return PlayerStatsResponse{}, err
+	}
+	statsRes, err := unmarshalFromReadCloser[playerStatsResponse](&res.Body)
+	if err != nil {
+		return PlayerStatsResponse{}, fmt.Errorf("%s: %w", unmarshalErr, err)
+	}
+	return statsRes.Stats, nil
+}
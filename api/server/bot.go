@@ -0,0 +1,46 @@
+package server
+
+import (
+	"battleship_client/board"
+	"math/rand"
+	"time"
+)
+
+const botNick = "WP_Bot"
+
+func newBot(rng *rand.Rand, now time.Time) *player {
+	bot := newPlayer("", botNick, "Local stand-in bot", board.RandomFleet(rng), now)
+	bot.bot = true
+	return bot
+}
+
+// Chooses the cell the bot fires at on the opponent's board.
+// Finishes off ships that were hit but not sunk, otherwise picks a random cell that can still contain a ship.
+func botTarget(rng *rand.Rand, opp *player) board.Coord {
+	// The bot sees only the results of its own shots.
+	view := board.Grid{}
+	for c := range opp.shotAt {
+		if opp.shipAt(c) != nil {
+			view.Set(c, board.Hit)
+		} else {
+			view.Set(c, board.Miss)
+		}
+	}
+	for _, ship := range opp.ships {
+		if opp.sunk(ship) {
+			view.MarkSunk(ship[0])
+		}
+	}
+	targets := make([]board.Coord, 0)
+	for _, c := range view.Find(board.Hit) {
+		for _, near := range c.Adjacent() {
+			if view.At(near) == board.Empty {
+				targets = append(targets, near)
+			}
+		}
+	}
+	if len(targets) == 0 {
+		targets = view.Find(board.Empty)
+	}
+	return targets[rng.Intn(len(targets))]
+}
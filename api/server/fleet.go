@@ -0,0 +1,15 @@
+package server
+
+import "battleship_client/board"
+
+// Checks that the coordinates form a valid fleet and groups them into ships.
+func checkFleet(coords []string) ([]board.Ship, error) {
+	if err := board.ValidateCoords(coords); err != nil {
+		return nil, err
+	}
+	cells, err := board.ParseCoords(coords)
+	if err != nil {
+		return nil, err
+	}
+	return board.Ships(cells), nil
+}
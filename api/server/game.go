@@ -0,0 +1,175 @@
+package server
+
+import (
+	"battleship_client/board"
+	"errors"
+	"slices"
+	"time"
+)
+
+const (
+	statusWaiting    = "waiting"
+	statusInProgress = "game_in_progress"
+	statusEnded      = "ended"
+	resultHit        = "hit"
+	resultMiss       = "miss"
+	resultSunk       = "sunk"
+	outcomeWin       = "win"
+	outcomeLose      = "lose"
+)
+
+var (
+	errNotInProgress = errors.New("game is not in progress")
+	errNotYourTurn   = errors.New("not your turn")
+	errInvalidCoord  = errors.New("invalid coord")
+	errAlreadyShot   = errors.New("coord was already shot")
+)
+
+type player struct {
+	token      string
+	nick       string
+	desc       string
+	targetNick string
+	bot        bool
+	ships      []board.Ship
+	// Shots fired by the opponent at the player's board, in order.
+	received []string
+	// Same shots as `received`, for quick lookup.
+	shotAt      map[board.Coord]bool
+	game        *game
+	idx         int
+	lastRefresh time.Time
+	outcome     string
+}
+
+type game struct {
+	players [2]*player
+	status  string
+	// Index of the player who should fire.
+	turn int
+	// Time the current turn started. Every shot starts a new turn.
+	turnStart time.Time
+	// Whether the result was added to the statistics.
+	recorded bool
+	// Time the game ended, zero while it is in progress.
+	endedAt time.Time
+}
+
+func newPlayer(token, nick, desc string, ships []board.Ship, now time.Time) *player {
+	return &player{
+		token:       token,
+		nick:        nick,
+		desc:        desc,
+		ships:       ships,
+		shotAt:      make(map[board.Coord]bool),
+		lastRefresh: now,
+	}
+}
+
+func (p *player) opponent() *player {
+	if p.game == nil {
+		return nil
+	}
+	return p.game.players[1-p.idx]
+}
+
+func (p *player) shouldFire() bool {
+	return p.game != nil && p.game.status == statusInProgress && p.game.turn == p.idx
+}
+
+// Returns the player's ship occupying the cell, or nil if the cell is empty.
+func (p *player) shipAt(c board.Coord) board.Ship {
+	for _, ship := range p.ships {
+		if slices.Contains(ship, c) {
+			return ship
+		}
+	}
+	return nil
+}
+
+// Checks whether all cells of the ship have been shot.
+func (p *player) sunk(ship board.Ship) bool {
+	for _, c := range ship {
+		if !p.shotAt[c] {
+			return false
+		}
+	}
+	return true
+}
+
+// Checks whether all the player's ships have been sunk.
+func (p *player) defeated() bool {
+	for _, ship := range p.ships {
+		if !p.sunk(ship) {
+			return false
+		}
+	}
+	return true
+}
+
+// Starts a game between two players. The first player fires first.
+func startGame(first, second *player, now time.Time) *game {
+	g := &game{
+		players:   [2]*player{first, second},
+		status:    statusInProgress,
+		turnStart: now,
+	}
+	for i, p := range g.players {
+		p.game = g
+		p.idx = i
+		p.outcome = ""
+	}
+	return g
+}
+
+// Fires a shot of the player at the opponent's board and returns the result.
+// The player keeps the turn after a hit, otherwise the turn passes to the opponent.
+func (g *game) fire(p *player, c board.Coord, now time.Time) (string, error) {
+	if g.status != statusInProgress {
+		return "", errNotInProgress
+	}
+	if g.turn != p.idx {
+		return "", errNotYourTurn
+	}
+	if !c.Valid() {
+		return "", errInvalidCoord
+	}
+	opp := p.opponent()
+	if opp.shotAt[c] {
+		return "", errAlreadyShot
+	}
+	opp.shotAt[c] = true
+	opp.received = append(opp.received, c.String())
+	g.turnStart = now
+
+	ship := opp.shipAt(c)
+	if ship == nil {
+		g.turn = opp.idx
+		return resultMiss, nil
+	}
+	if opp.defeated() {
+		g.end(p, now)
+		return resultSunk, nil
+	}
+	if opp.sunk(ship) {
+		return resultSunk, nil
+	}
+	return resultHit, nil
+}
+
+// Finishes the game with the given winner.
+func (g *game) end(winner *player, now time.Time) {
+	g.status = statusEnded
+	g.endedAt = now
+	winner.outcome = outcomeWin
+	g.players[1-winner.idx].outcome = outcomeLose
+}
+
+// Returns the seconds left until the end of the current turn.
+func (g *game) timeLeft(turnTime time.Duration, now time.Time) int {
+	left := turnTime - now.Sub(g.turnStart)
+	if left < 0 {
+		return 0
+	}
+	return int(left.Seconds())
+}
@@ -0,0 +1,322 @@
+// Package server implements a local stand-in for the battleship game server.
+// It serves the same HTTP API as the public server under the "/api" prefix, keeps all games in memory,
+// and offers a bot opponent as well as matchmaking between two human players.
+package server
+
+import (
+	"battleship_client/api/client"
+	"battleship_client/board"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	mrand "math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const tokenKey = "X-Auth-Token"
+
+type Options struct {
+	// Time a player has for a single shot. The player who does not fire in time loses.
+	TurnTime time.Duration
+	// Time after which a waiting game that was not refreshed is removed from the lobby.
+	// A player whose game ended is removed after the same time if they do not ask for its status.
+	WaitTimeout time.Duration
+	// Time the bot waits before each of its shots.
+	BotDelay time.Duration
+	// Seed of the random generator used for the bot and random fleets. Zero means a time based seed.
+	Seed int64
+}
+
+func DefaultOptions() Options {
+	return Options{
+		TurnTime:    time.Second * 60,
+		WaitTimeout: time.Second * 60,
+		BotDelay:    time.Millisecond * 500,
+	}
+}
+
+type Server struct {
+	opts    Options
+	mux     *http.ServeMux
+	mu      sync.Mutex
+	rng     *mrand.Rand
+	players map[string]*player
+	// Players waiting for an opponent, in order of joining.
+	waiting []*player
+	// Statistics of finished games by the nick of the player.
+	stats map[string]*client.PlayerStatsResponse
+	now   func() time.Time
+}
+
+// Creates a server with all the routes of the game API registered.
+func New(opts Options) *Server {
+	seed := opts.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	s := &Server{
+		opts:    opts,
+		mux:     http.NewServeMux(),
+		rng:     mrand.New(mrand.NewSource(seed)),
+		players: make(map[string]*player),
+		stats:   make(map[string]*client.PlayerStatsResponse),
+		now:     time.Now,
+	}
+	s.mux.HandleFunc("POST /api/game", s.handleNewGame)
+	s.mux.HandleFunc("GET /api/game", s.withPlayer(s.handleStatus))
+	s.mux.HandleFunc("GET /api/game/board", s.withPlayer(s.handleBoard))
+	s.mux.HandleFunc("POST /api/game/fire", s.withPlayer(s.handleFire))
+	s.mux.HandleFunc("GET /api/game/desc", s.withPlayer(s.handleDescription))
+	s.mux.HandleFunc("GET /api/game/refresh", s.withPlayer(s.handleRefresh))
+	s.mux.HandleFunc("DELETE /api/game/abandon", s.withPlayer(s.handleAbandon))
+	s.mux.HandleFunc("GET /api/lobby", s.handleLobby)
+	s.mux.HandleFunc("GET /api/stats", s.handleStats)
+	s.mux.HandleFunc("GET /api/stats/{nick}", s.handlePlayerStats)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Wraps the handler so that it is called with the lock held and the player identified by the token header.
+// Updates the player's game before calling the handler.
+func (s *Server) withPlayer(handler func(http.ResponseWriter, *http.Request, *player)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.expire()
+		p, ok := s.players[r.Header.Get(tokenKey)]
+		if !ok {
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		if p.game != nil {
+			s.update(p.game)
+		}
+		handler(w, r, p)
+	}
+}
+
+func (s *Server) handleNewGame(w http.ResponseWriter, r *http.Request) {
+	settings := client.GameSettings{}
+	if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return
+	}
+	if err := settings.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+
+	var ships []board.Ship
+	if len(settings.Coords) == 0 {
+		ships = board.RandomFleet(s.rng)
+	} else {
+		var err error
+		ships, err = checkFleet(settings.Coords)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	nick := settings.Nick
+	if nick == "" {
+		nick = fmt.Sprintf("Player_%d", s.rng.Intn(10000))
+	}
+	now := s.now()
+	p := newPlayer(newToken(), nick, settings.Description, ships, now)
+	p.targetNick = settings.TargetNick
+	s.players[p.token] = p
+
+	switch {
+	case settings.AgainstBot:
+		startGame(p, newBot(s.rng, now), now)
+	default:
+		s.matchmake(p, now)
+	}
+	w.Header().Set(tokenKey, p.token)
+	writeJSON(w, http.StatusOK, map[string]string{})
+}
+
+// Pairs the player with a waiting opponent if one of them challenged the other, otherwise puts the player in the lobby.
+// A player with no target nick hosts a game and waits until someone challenges them.
+func (s *Server) matchmake(p *player, now time.Time) {
+	for i, w := range s.waiting {
+		challenged := p.targetNick == w.nick && (w.targetNick == "" || w.targetNick == p.nick)
+		challenger := w.targetNick == p.nick && p.targetNick == ""
+		if challenged || challenger {
+			s.waiting = append(s.waiting[:i], s.waiting[i+1:]...)
+			startGame(w, p, now)
+			return
+		}
+	}
+	s.waiting = append(s.waiting, p)
+}
+
+// Removes players from the lobby if they did not refresh their session in time,
+// and the players who did not ask for the status of their game since it ended.
+func (s *Server) expire() {
+	now := s.now()
+	waiting := s.waiting[:0]
+	for _, p := range s.waiting {
+		if now.Sub(p.lastRefresh) > s.opts.WaitTimeout {
+			delete(s.players, p.token)
+			continue
+		}
+		waiting = append(waiting, p)
+	}
+	s.waiting = waiting
+	for token, p := range s.players {
+		if g := p.game; g != nil && g.status == statusEnded && now.Sub(g.endedAt) > s.opts.WaitTimeout {
+			delete(s.players, token)
+		}
+	}
+}
+
+// Brings the game up to date: lets the bot fire when it is its turn, and ends the game when the turn time runs out.
+func (s *Server) update(g *game) {
+	defer s.recordResult(g)
+	for g.status == statusInProgress {
+		now := s.now()
+		p := g.players[g.turn]
+		elapsed := now.Sub(g.turnStart)
+		if p.bot {
+			if elapsed < s.opts.BotDelay {
+				return
+			}
+			shotTime := g.turnStart.Add(s.opts.BotDelay)
+			g.fire(p, botTarget(s.rng, p.opponent()), shotTime)
+			continue
+		}
+		if elapsed > s.opts.TurnTime {
+			g.end(p.opponent(), g.turnStart.Add(s.opts.TurnTime))
+		}
+		return
+	}
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request, p *player) {
+	res := client.StatusResponse{
+		Status:         statusWaiting,
+		LastGameStatus: p.outcome,
+		Nick:           p.nick,
+		OpponentShots:  p.received,
+	}
+	if p.game != nil {
+		res.Status = p.game.status
+		res.Opponent = p.opponent().nick
+		res.ShouldFire = p.shouldFire()
+		res.Timer = p.game.timeLeft(s.opts.TurnTime, s.now())
+	}
+	if res.OpponentShots == nil {
+		res.OpponentShots = []string{}
+	}
+	writeJSON(w, http.StatusOK, res)
+	// The player learned the outcome, so the token is no longer needed.
+	if res.Status == statusEnded {
+		delete(s.players, p.token)
+	}
+}
+
+func (s *Server) handleBoard(w http.ResponseWriter, r *http.Request, p *player) {
+	coords := make([]string, 0, board.FleetCells)
+	for _, ship := range p.ships {
+		coords = append(coords, board.FormatCoords(ship)...)
+	}
+	writeJSON(w, http.StatusOK, map[string][]string{"board": coords})
+}
+
+func (s *Server) handleFire(w http.ResponseWriter, r *http.Request, p *player) {
+	body := map[string]string{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return
+	}
+	if p.game == nil {
+		writeError(w, http.StatusBadRequest, errNotInProgress.Error())
+		return
+	}
+	c, err := board.ParseCoord(body["coord"])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("%s: %s", errInvalidCoord, err))
+		return
+	}
+	result, err := p.game.fire(p, c, s.now())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	s.update(p.game)
+	writeJSON(w, http.StatusOK, map[string]string{"result": result})
+}
+
+func (s *Server) handleDescription(w http.ResponseWriter, r *http.Request, p *player) {
+	opp := p.opponent()
+	if opp == nil {
+		writeError(w, http.StatusNotFound, "game not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{
+		"desc":     p.desc,
+		"nick":     p.nick,
+		"opp_desc": opp.desc,
+		"opponent": opp.nick,
+	})
+}
+
+func (s *Server) handleRefresh(w http.ResponseWriter, r *http.Request, p *player) {
+	p.lastRefresh = s.now()
+	writeJSON(w, http.StatusOK, map[string]string{})
+}
+
+// Removes the player from the server. If the game is in progress, the opponent wins.
+func (s *Server) handleAbandon(w http.ResponseWriter, r *http.Request, p *player) {
+	if g := p.game; g != nil && g.status == statusInProgress {
+		g.end(p.opponent(), s.now())
+		s.recordResult(g)
+	}
+	for i, wp := range s.waiting {
+		if wp == p {
+			s.waiting = append(s.waiting[:i], s.waiting[i+1:]...)
+			break
+		}
+	}
+	delete(s.players, p.token)
+	writeJSON(w, http.StatusOK, map[string]string{})
+}
+
+func (s *Server) handleLobby(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+	games := make([]client.LobbyGame, 0, len(s.waiting))
+	for _, p := range s.waiting {
+		games = append(games, client.LobbyGame{Status: statusWaiting, Nick: p.nick})
+	}
+	writeJSON(w, http.StatusOK, games)
+}
+
+func newToken() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("failed to generate token: %s", err))
+	}
+	return hex.EncodeToString(b)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"message": message})
+}
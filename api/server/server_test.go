@@ -0,0 +1,273 @@
+package server
+
+import (
+	"battleship_client/api/client"
+	"battleship_client/board"
+	"context"
+	"errors"
+	"math/rand"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Time of the server that only moves when the test advances it.
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Starts the server on a local port and returns the options of the clients that connect to it.
+func startServer(t *testing.T, opts Options) (client.Options, *testClock) {
+	t.Helper()
+	clock := &testClock{now: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)}
+	s := New(opts)
+	s.now = clock.Now
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+	return client.Options{BaseURL: srv.URL + "/api"}, clock
+}
+
+func testOptions() Options {
+	return Options{
+		TurnTime:    time.Minute,
+		WaitTimeout: time.Minute,
+		Seed:        1,
+	}
+}
+
+// Returns a valid fleet that is the same for the same seed.
+func testFleet(seed int64) []board.Ship {
+	return board.RandomFleet(rand.New(rand.NewSource(seed)))
+}
+
+func join(t *testing.T, opts client.Options, gs client.GameSettings) client.GameClient {
+	t.Helper()
+	g, err := client.InitGame(gs, opts)
+	if err != nil {
+		t.Fatalf("InitGame(%+v) error: %s", gs, err)
+	}
+	return g
+}
+
+func status(t *testing.T, g client.GameClient) client.StatusResponse {
+	t.Helper()
+	res, err := g.Status()
+	if err != nil {
+		t.Fatalf("Status() error: %s", err)
+	}
+	return res
+}
+
+func TestLobby(t *testing.T) {
+	tests := []struct {
+		name  string
+		joins []client.GameSettings
+		// Time that passes after all the players joined.
+		wait        time.Duration
+		wantLobby   []string
+		wantStarted bool
+	}{
+		{
+			name:      "host waits for a challenge",
+			joins:     []client.GameSettings{{Nick: "alice"}},
+			wantLobby: []string{"alice"},
+		},
+		{
+			name:        "challenger joins the host",
+			joins:       []client.GameSettings{{Nick: "alice"}, {Nick: "bob", TargetNick: "alice"}},
+			wantLobby:   []string{},
+			wantStarted: true,
+		},
+		{
+			name:        "players challenge each other",
+			joins:       []client.GameSettings{{Nick: "alice", TargetNick: "bob"}, {Nick: "bob", TargetNick: "alice"}},
+			wantLobby:   []string{},
+			wantStarted: true,
+		},
+		{
+			name:      "host challenged someone else",
+			joins:     []client.GameSettings{{Nick: "alice", TargetNick: "carol"}, {Nick: "bob", TargetNick: "alice"}},
+			wantLobby: []string{"alice", "bob"},
+		},
+		{
+			name:      "host expires without a refresh",
+			joins:     []client.GameSettings{{Nick: "alice"}},
+			wait:      time.Minute + time.Second,
+			wantLobby: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, clock := startServer(t, testOptions())
+			players := make([]client.GameClient, 0, len(tt.joins))
+			for _, gs := range tt.joins {
+				players = append(players, join(t, opts, gs))
+			}
+			clock.Advance(tt.wait)
+
+			games, err := client.Lobby(opts)
+			if err != nil {
+				t.Fatalf("Lobby() error: %s", err)
+			}
+			nicks := make([]string, 0, len(games))
+			for _, g := range games {
+				nicks = append(nicks, g.Nick)
+			}
+			if !slices.Equal(nicks, tt.wantLobby) {
+				t.Errorf("lobby = %v, want %v", nicks, tt.wantLobby)
+			}
+			if !tt.wantStarted {
+				return
+			}
+			for i, p := range players {
+				res := status(t, p)
+				if res.Status != statusInProgress {
+					t.Errorf("status of %s = %q, want %q", tt.joins[i].Nick, res.Status, statusInProgress)
+				}
+				if want := tt.joins[1-i].Nick; res.Opponent != want {
+					t.Errorf("opponent of %s = %q, want %q", tt.joins[i].Nick, res.Opponent, want)
+				}
+			}
+		})
+	}
+}
+
+func TestBotGame(t *testing.T) {
+	opts, _ := startServer(t, testOptions())
+	g := join(t, opts, client.GameSettings{Nick: "alice", AgainstBot: true})
+	if res := status(t, g); res.Status != statusInProgress || res.Opponent != botNick || !res.ShouldFire {
+		t.Fatalf("status = %+v, want the player's turn against the bot", res)
+	}
+
+	// The bot fires as soon as the player misses, so the player can shoot every cell in order until the game ends.
+	var res client.StatusResponse
+	for _, c := range board.AllCoords() {
+		if _, err := g.Fire(c.String()); err != nil {
+			t.Fatalf("Fire(%s) error: %s", c, err)
+		}
+		if res = status(t, g); res.Status == statusEnded {
+			break
+		}
+		if !res.ShouldFire {
+			t.Fatalf("status after the shot at %s = %+v, want the player's turn", c, res)
+		}
+	}
+	if res.Status != statusEnded {
+		t.Fatalf("game did not end after shooting every cell")
+	}
+	if res.LastGameStatus != outcomeWin && res.LastGameStatus != outcomeLose {
+		t.Errorf("outcome = %q, want %q or %q", res.LastGameStatus, outcomeWin, outcomeLose)
+	}
+
+	// The player is removed once they learned the outcome.
+	if _, err := g.Status(); !errors.Is(err, client.ErrUnauthorized) {
+		t.Errorf("status after the end error = %v, want %v", err, client.ErrUnauthorized)
+	}
+	// Games against the bot are not counted.
+	stats, err := g.Stats()
+	if err != nil {
+		t.Fatalf("Stats() error: %s", err)
+	}
+	if len(stats) != 0 {
+		t.Errorf("stats = %+v, want none", stats)
+	}
+}
+
+func TestFire(t *testing.T) {
+	opts, _ := startServer(t, testOptions())
+	bobShips := testFleet(2)
+	alice := join(t, opts, client.GameSettings{Nick: "alice", Coords: board.FormatCoords(board.Cells(testFleet(1)))})
+	bob := join(t, opts, client.GameSettings{Nick: "bob", TargetNick: "alice", Coords: board.FormatCoords(board.Cells(bobShips))})
+
+	bobGrid := board.Grid{}
+	for _, c := range board.Cells(bobShips) {
+		bobGrid.Set(c, board.Occupied)
+	}
+	// The longest ship is placed first, so it is not sunk by a single hit.
+	hit := bobShips[0][0].String()
+	miss := bobGrid.Find(board.Empty)[0].String()
+
+	players := map[string]client.GameClient{"alice": alice, "bob": bob}
+	steps := []struct {
+		name    string
+		shooter string
+		coord   string
+		want    string
+		wantErr error
+		// Player who should fire after the shot.
+		wantTurn string
+	}{
+		{name: "host fires first", shooter: "bob", coord: "A1", wantErr: client.ErrNotYourTurn, wantTurn: "alice"},
+		{name: "coordinate outside of the board", shooter: "alice", coord: "K1", wantErr: client.ErrInvalidCoord, wantTurn: "alice"},
+		{name: "hit keeps the turn", shooter: "alice", coord: hit, want: resultHit, wantTurn: "alice"},
+		{name: "cell shot twice", shooter: "alice", coord: hit, wantErr: client.ErrInvalidCoord, wantTurn: "alice"},
+		{name: "miss passes the turn", shooter: "alice", coord: miss, want: resultMiss, wantTurn: "bob"},
+	}
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			result, err := players[step.shooter].Fire(step.coord)
+			if step.wantErr != nil {
+				if !errors.Is(err, step.wantErr) {
+					t.Errorf("Fire(%s) error = %v, want %v", step.coord, err, step.wantErr)
+				}
+			} else if err != nil || result != step.want {
+				t.Errorf("Fire(%s) = %q, %v, want %q", step.coord, result, err, step.want)
+			}
+			for nick, p := range players {
+				if res := status(t, p); res.ShouldFire != (nick == step.wantTurn) {
+					t.Errorf("should_fire of %s = %t, want the turn of %s", nick, res.ShouldFire, step.wantTurn)
+				}
+			}
+		})
+	}
+
+	if res := status(t, bob); !slices.Equal(res.OpponentShots, []string{hit, miss}) {
+		t.Errorf("shots at bob = %v, want %v", res.OpponentShots, []string{hit, miss})
+	}
+	if err := bob.Abandon(); err != nil {
+		t.Fatalf("Abandon() error: %s", err)
+	}
+	if res := status(t, alice); res.Status != statusEnded || res.LastGameStatus != outcomeWin {
+		t.Errorf("status of alice after bob abandoned = %+v, want a win", res)
+	}
+	st, err := alice.PlayerStats("alice")
+	if err != nil {
+		t.Fatalf("PlayerStats(alice) error: %s", err)
+	}
+	if st.Games != 1 || st.Wins != 1 || st.Points != winPoints {
+		t.Errorf("stats of alice = %+v, want 1 game won", st)
+	}
+	if _, err := alice.PlayerStats("carol"); !errors.Is(err, client.ErrPlayerNotFound) {
+		t.Errorf("PlayerStats(carol) error = %v, want %v", err, client.ErrPlayerNotFound)
+	}
+}
+
+func TestFinishedPlayerExpires(t *testing.T) {
+	opts, clock := startServer(t, testOptions())
+	alice := join(t, opts, client.GameSettings{Nick: "alice"})
+	bob := join(t, opts, client.GameSettings{Nick: "bob", TargetNick: "alice"})
+	if err := bob.AbandonContext(context.Background()); err != nil {
+		t.Fatalf("Abandon() error: %s", err)
+	}
+
+	// Alice never asks for the status of the ended game, so she is removed after the wait timeout.
+	clock.Advance(time.Minute + time.Second)
+	if _, err := alice.Status(); !errors.Is(err, client.ErrUnauthorized) {
+		t.Errorf("status of the expired player error = %v, want %v", err, client.ErrUnauthorized)
+	}
+}
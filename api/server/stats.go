@@ -0,0 +1,88 @@
+package server
+
+import (
+	"battleship_client/api/client"
+	"net/http"
+	"sort"
+)
+
+const (
+	// Points a player gets for winning and losing a game.
+	winPoints  = 3
+	losePoints = 0
+	// Number of players returned by the ranking.
+	rankingSize = 10
+)
+
+// Adds the result of the finished game to the statistics of both players. Games against the bot are not counted.
+// Does nothing if the game is still in progress or was already recorded.
+func (s *Server) recordResult(g *game) {
+	if g.status != statusEnded || g.recorded {
+		return
+	}
+	g.recorded = true
+	for _, p := range g.players {
+		if p.bot || g.players[1-p.idx].bot {
+			return
+		}
+	}
+	for _, p := range g.players {
+		st, ok := s.stats[p.nick]
+		if !ok {
+			st = &client.PlayerStatsResponse{Nick: p.nick}
+			s.stats[p.nick] = st
+		}
+		st.Games++
+		if p.outcome == outcomeWin {
+			st.Wins++
+			st.Points += winPoints
+		} else {
+			st.Points += losePoints
+		}
+	}
+}
+
+// Returns the statistics of all players ordered by points, then wins, with the ranks filled in.
+func (s *Server) ranking() []client.PlayerStatsResponse {
+	ranking := make([]client.PlayerStatsResponse, 0, len(s.stats))
+	for _, st := range s.stats {
+		ranking = append(ranking, *st)
+	}
+	sort.Slice(ranking, func(i, j int) bool {
+		a, b := ranking[i], ranking[j]
+		if a.Points != b.Points {
+			return a.Points > b.Points
+		}
+		if a.Wins != b.Wins {
+			return a.Wins > b.Wins
+		}
+		return a.Nick < b.Nick
+	})
+	for i := range ranking {
+		ranking[i].Rank = i + 1
+	}
+	return ranking
+}
+
+func (s *Server) handleStats(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ranking := s.ranking()
+	if len(ranking) > rankingSize {
+		ranking = ranking[:rankingSize]
+	}
+	writeJSON(w, http.StatusOK, map[string][]client.PlayerStatsResponse{"stats": ranking})
+}
+
+func (s *Server) handlePlayerStats(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	nick := r.PathValue("nick")
+	for _, st := range s.ranking() {
+		if st.Nick == nick {
+			writeJSON(w, http.StatusOK, map[string]client.PlayerStatsResponse{"stats": st})
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "player not found")
+}
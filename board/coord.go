@@ -0,0 +1,118 @@
+// Package board contains the rules of the game board that do not depend on any user interface:
+// coordinates, cell states, ships and the fleet.
+package board
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Number of rows and columns of the board.
+const Size = 10
+
+// Position of a cell on the board. X is the index of the letter and Y the index of the number,
+// so "A1" is {0, 0} and "J10" is {9, 9}.
+type Coord struct {
+	X int
+	Y int
+}
+
+// Converts a coordinate in the server format, e.g. "B7", into a `Coord`.
+func ParseCoord(coord string) (Coord, error) {
+	c := Coord{}
+	if length := len(coord); length < 2 || length > 3 {
+		return c, fmt.Errorf("bad format")
+	}
+	c.X = int(coord[0]) - 'A'
+	if c.X < 0 || c.X >= Size {
+		return c, fmt.Errorf("letter coord is out of bounds")
+	}
+	// Unlike `strconv.Atoi`, a sign is not a part of the number, e.g. "A+1" is invalid.
+	if coord[1] < '0' || coord[1] > '9' {
+		return c, fmt.Errorf("number coord is not a number")
+	}
+	num, err := strconv.Atoi(coord[1:])
+	if err != nil {
+		return c, fmt.Errorf("failed to convert number coord to integer: %w", err)
+	}
+	c.Y = num - 1
+	if c.Y < 0 || c.Y >= Size {
+		return c, fmt.Errorf("number coord is out of bounds")
+	}
+	return c, nil
+}
+
+// Converts all the coordinates in the server format. Fails on the first invalid one.
+func ParseCoords(coords []string) ([]Coord, error) {
+	out := make([]Coord, len(coords))
+	for i, coord := range coords {
+		c, err := ParseCoord(coord)
+		if err != nil {
+			return nil, fmt.Errorf("invalid coord %q: %w", coord, err)
+		}
+		out[i] = c
+	}
+	return out, nil
+}
+
+// Converts the coordinates into the server format.
+func FormatCoords(coords []Coord) []string {
+	out := make([]string, len(coords))
+	for i, c := range coords {
+		out[i] = c.String()
+	}
+	return out
+}
+
+// Returns the coordinate in the server format. Invalid coordinates are formatted as "??".
+func (c Coord) String() string {
+	if !c.Valid() {
+		return "??"
+	}
+	return fmt.Sprintf("%c%d", 'A'+c.X, c.Y+1)
+}
+
+// Reports whether the coordinate lies on the board.
+func (c Coord) Valid() bool {
+	return c.X >= 0 && c.X < Size && c.Y >= 0 && c.Y < Size
+}
+
+// Returns the coordinate moved by the given offsets. The result may be outside of the board.
+func (c Coord) Add(dx, dy int) Coord {
+	return Coord{X: c.X + dx, Y: c.Y + dy}
+}
+
+// Returns the cells on the board that touch the coordinate, including the diagonal ones.
+func (c Coord) Neighbours() []Coord {
+	out := make([]Coord, 0, 8)
+	for i := -1; i <= 1; i++ {
+		for j := -1; j <= 1; j++ {
+			if n := c.Add(i, j); (i != 0 || j != 0) && n.Valid() {
+				out = append(out, n)
+			}
+		}
+	}
+	return out
+}
+
+// Returns the cells on the board that share a side with the coordinate.
+func (c Coord) Adjacent() []Coord {
+	out := make([]Coord, 0, 4)
+	for _, d := range [][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
+		if n := c.Add(d[0], d[1]); n.Valid() {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+// Returns all the coordinates of the board, column by column.
+func AllCoords() []Coord {
+	out := make([]Coord, 0, Size*Size)
+	for x := 0; x < Size; x++ {
+		for y := 0; y < Size; y++ {
+			out = append(out, Coord{X: x, Y: y})
+		}
+	}
+	return out
+}
@@ -0,0 +1,43 @@
+package board
+
+import "testing"
+
+func TestParseCoord(t *testing.T) {
+	tests := []struct {
+		coord   string
+		want    Coord
+		wantErr bool
+	}{
+		{coord: "A1", want: Coord{X: 0, Y: 0}},
+		{coord: "B7", want: Coord{X: 1, Y: 6}},
+		{coord: "J10", want: Coord{X: 9, Y: 9}},
+		{coord: "", wantErr: true},
+		{coord: "A", wantErr: true},
+		{coord: "A100", wantErr: true},
+		{coord: "K1", wantErr: true},
+		{coord: "a1", wantErr: true},
+		{coord: "A0", wantErr: true},
+		{coord: "A11", wantErr: true},
+		{coord: "AB", wantErr: true},
+		{coord: "A+1", wantErr: true},
+		{coord: "A-1", wantErr: true},
+		{coord: "A 1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.coord, func(t *testing.T) {
+			got, err := ParseCoord(tt.coord)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseCoord(%q) = %v, want an error", tt.coord, got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ParseCoord(%q) = %v, %v, want %v", tt.coord, got, err, tt.want)
+			}
+			if s := got.String(); s != tt.coord {
+				t.Errorf("String() = %q, want %q", s, tt.coord)
+			}
+		})
+	}
+}
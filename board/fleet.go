@@ -0,0 +1,87 @@
+package board
+
+import "slices"
+
+// Lengths of the ships that every fleet consists of: one four-tile ship, two three-tile ships,
+// three two-tile ships and four single-tile ships.
+var Fleet = []int{4, 3, 3, 2, 2, 2, 1, 1, 1, 1}
+
+// Number of cells occupied by the whole fleet.
+const FleetCells = 20
+
+// Cells occupied by a single ship.
+type Ship []Coord
+
+// Returns the cells that touch the ship, including diagonals, but are not a part of it.
+func (s Ship) Surroundings() []Coord {
+	out := make([]Coord, 0)
+	for _, c := range s {
+		for _, n := range c.Neighbours() {
+			if !slices.Contains(s, n) && !slices.Contains(out, n) {
+				out = append(out, n)
+			}
+		}
+	}
+	return out
+}
+
+// Reports whether the ship consists of the same cells as the other one, regardless of the order.
+func (s Ship) Equal(other Ship) bool {
+	if len(s) != len(other) {
+		return false
+	}
+	for _, c := range s {
+		if !slices.Contains(other, c) {
+			return false
+		}
+	}
+	return true
+}
+
+// Groups the coordinates into ships. Cells touching each other, diagonals included, belong to the same ship.
+func Ships(coords []Coord) []Ship {
+	g := Grid{}
+	for _, c := range coords {
+		g.Set(c, Occupied)
+	}
+	return g.Ships()
+}
+
+// Returns all the ships on the grid, regardless of whether they were shot.
+func (g *Grid) Ships() []Ship {
+	ships := make([]Ship, 0)
+	visited := Grid{}
+	for _, c := range AllCoords() {
+		if !g.At(c).IsShip() || visited.At(c) != Empty {
+			continue
+		}
+		cluster, _ := g.cluster(c, Cell.IsShip)
+		for _, s := range cluster {
+			visited.Set(s, Occupied)
+		}
+		ships = append(ships, Ship(cluster))
+	}
+	return ships
+}
+
+// Returns the ships on the grid that were sunk.
+func (g *Grid) SunkShips() []Ship {
+	ships := make([]Ship, 0)
+	for _, ship := range g.Ships() {
+		if g.At(ship[0]) == Sunk {
+			ships = append(ships, ship)
+		}
+	}
+	return ships
+}
+
+// Returns the lengths of the fleet ships that are not among the given sunk ships.
+func Remaining(sunk []Ship) []int {
+	remaining := slices.Clone(Fleet)
+	for _, s := range sunk {
+		if i := slices.Index(remaining, len(s)); i >= 0 {
+			remaining = slices.Delete(remaining, i, i+1)
+		}
+	}
+	return remaining
+}
@@ -0,0 +1,88 @@
+package board
+
+import "math/rand"
+
+// Places the whole fleet on an empty board at random, so that no two ships touch each other, not even diagonally.
+// The same generator state always produces the same fleet.
+func RandomFleet(rng *rand.Rand) []Ship {
+	for {
+		ships, ok := tryRandomFleet(rng)
+		if ok {
+			return ships
+		}
+	}
+}
+
+// Places the ships from the longest to the shortest, each one at a random position out of all the valid ones.
+// Fails if some ship does not fit anywhere.
+func tryRandomFleet(rng *rand.Rand) ([]Ship, bool) {
+	ships := make([]Ship, 0, len(Fleet))
+	blocked := Grid{}
+	for _, length := range Fleet {
+		positions := freePositions(blocked, length)
+		if len(positions) == 0 {
+			return nil, false
+		}
+		ship := positions[rng.Intn(len(positions))]
+		for _, c := range ship {
+			blocked.Set(c, Occupied)
+		}
+		for _, c := range ship.Surroundings() {
+			blocked.Set(c, Miss)
+		}
+		ships = append(ships, ship)
+	}
+	return ships, true
+}
+
+// Returns all straight ships of the given length that fit on the grid using only empty cells.
+func freePositions(g Grid, length int) []Ship {
+	out := make([]Ship, 0)
+	directions := [][2]int{{1, 0}, {0, 1}}
+	if length == 1 {
+		directions = directions[:1]
+	}
+	for _, start := range AllCoords() {
+		for _, d := range directions {
+			if ship, ok := StraightShip(start, length, d[0] == 0); ok && g.allEmpty(ship) {
+				out = append(out, ship)
+			}
+		}
+	}
+	return out
+}
+
+// Returns the ship of the given length starting at the coordinate and going right or, if vertical, down.
+// Reports false if the ship does not fit on the board.
+func StraightShip(start Coord, length int, vertical bool) (Ship, bool) {
+	dx, dy := 1, 0
+	if vertical {
+		dx, dy = 0, 1
+	}
+	ship := make(Ship, length)
+	for i := range ship {
+		ship[i] = start.Add(dx*i, dy*i)
+		if !ship[i].Valid() {
+			return nil, false
+		}
+	}
+	return ship, true
+}
+
+func (g *Grid) allEmpty(coords []Coord) bool {
+	for _, c := range coords {
+		if g.At(c) != Empty {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the cells of all the ships as a single slice.
+func Cells(ships []Ship) []Coord {
+	out := make([]Coord, 0, FleetCells)
+	for _, ship := range ships {
+		out = append(out, ship...)
+	}
+	return out
+}
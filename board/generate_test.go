@@ -0,0 +1,27 @@
+package board
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestRandomFleet(t *testing.T) {
+	for seed := int64(1); seed <= 50; seed++ {
+		ships := RandomFleet(rand.New(rand.NewSource(seed)))
+		if err := ValidateFleet(Cells(ships)); err != nil {
+			t.Fatalf("fleet of seed %d is invalid: %s", seed, err)
+		}
+		lengths := make([]int, 0, len(ships))
+		for _, ship := range ships {
+			lengths = append(lengths, len(ship))
+		}
+		if !slices.Equal(lengths, Fleet) {
+			t.Errorf("lengths of the ships of seed %d = %v, want %v", seed, lengths, Fleet)
+		}
+		again := RandomFleet(rand.New(rand.NewSource(seed)))
+		if !slices.EqualFunc(ships, again, Ship.Equal) {
+			t.Errorf("seed %d gave different fleets %v and %v", seed, ships, again)
+		}
+	}
+}
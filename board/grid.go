@@ -0,0 +1,94 @@
+package board
+
+import "slices"
+
+// State of a single cell of the board.
+type Cell uint8
+
+const (
+	Empty Cell = iota
+	// Cell occupied by a ship that was not shot.
+	Occupied
+	// Cell of a ship that was shot, but the ship is still afloat.
+	Hit
+	// Cell of a sunk ship.
+	Sunk
+	// Cell that was shot and turned out empty, or is known to be empty.
+	Miss
+)
+
+// Reports whether the cell belongs to a ship, shot or not.
+func (c Cell) IsShip() bool {
+	return c == Occupied || c == Hit || c == Sunk
+}
+
+// States of all the cells of the board, indexed the same way as `Coord`.
+type Grid [Size][Size]Cell
+
+func (g *Grid) At(c Coord) Cell {
+	return g[c.X][c.Y]
+}
+
+func (g *Grid) Set(c Coord, cell Cell) {
+	g[c.X][c.Y] = cell
+}
+
+// Returns all the coordinates with the given state.
+func (g *Grid) Find(cell Cell) []Coord {
+	out := make([]Coord, 0)
+	for _, c := range AllCoords() {
+		if g.At(c) == cell {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Returns the group of cells connected to the given one (diagonals included) that have the same state,
+// and the cells with a different state that surround the group.
+func (g *Grid) Cluster(c Coord) (cluster, surroundings []Coord) {
+	return g.cluster(c, func(cell Cell) bool { return cell == g.At(c) })
+}
+
+// Marks the ship containing the hit cell as sunk, and all the cells around it as missed,
+// because no other ship can touch it. Returns the cells of the sunk ship.
+func (g *Grid) MarkSunk(c Coord) Ship {
+	if !g.At(c).IsShip() || g.At(c) == Occupied {
+		return nil
+	}
+	cluster, surroundings := g.cluster(c, func(cell Cell) bool { return cell == Hit || cell == Sunk })
+	for _, s := range cluster {
+		g.Set(s, Sunk)
+	}
+	for _, s := range surroundings {
+		if g.At(s) == Empty {
+			g.Set(s, Miss)
+		}
+	}
+	return Ship(cluster)
+}
+
+// Walks through the cells connected to `c`, including diagonals, for which `member` returns true.
+// Each cell appears only once in the returned slices.
+func (g *Grid) cluster(c Coord, member func(Cell) bool) (cluster, surroundings []Coord) {
+	toVisit := []Coord{c}
+	cluster = []Coord{c}
+	surroundings = make([]Coord, 0)
+	for len(toVisit) > 0 {
+		n := len(toVisit) - 1
+		current := toVisit[n]
+		toVisit = toVisit[:n]
+		for _, near := range current.Neighbours() {
+			if slices.Contains(cluster, near) || slices.Contains(surroundings, near) {
+				continue
+			}
+			if member(g.At(near)) {
+				cluster = append(cluster, near)
+				toVisit = append(toVisit, near)
+				continue
+			}
+			surroundings = append(surroundings, near)
+		}
+	}
+	return
+}
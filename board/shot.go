@@ -0,0 +1,33 @@
+package board
+
+import "fmt"
+
+// Results of a shot returned by the server.
+const (
+	ResultHit  = "hit"
+	ResultMiss = "miss"
+	ResultSunk = "sunk"
+)
+
+// Marks the result of the shot at the cell on the view of the opponent's board.
+// The cells around a sunk ship are marked as missed. Returns the cells of the ship if the shot sunk it.
+func (g *Grid) ApplyResult(c Coord, result string) (Ship, error) {
+	if !c.Valid() {
+		return nil, fmt.Errorf("invalid coord %s", c)
+	}
+	switch result {
+	case ResultHit:
+		g.Set(c, Hit)
+	case ResultSunk:
+		g.Set(c, Hit)
+		return g.MarkSunk(c), nil
+	case ResultMiss:
+		// The cell may already be known to be empty, e.g. next to a sunk ship.
+		if g.At(c) == Empty {
+			g.Set(c, Miss)
+		}
+	default:
+		return nil, fmt.Errorf("unknown shot result %q", result)
+	}
+	return nil, nil
+}
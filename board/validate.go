@@ -0,0 +1,175 @@
+package board
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Errors describing why a fleet is invalid. They can be matched with `errors.Is`.
+var (
+	ErrFleetSize        = errors.New("wrong number of ship cells")
+	ErrInvalidCoord     = errors.New("invalid coordinate")
+	ErrDuplicateCoord   = errors.New("duplicated coordinate")
+	ErrShipShape        = errors.New("ship is not a straight line")
+	ErrShipsTouch       = errors.New("ships touch each other")
+	ErrFleetComposition = errors.New("wrong fleet composition")
+)
+
+// Error returned by the fleet validation. Describes the kind of the problem and the cells that cause it.
+type FleetError struct {
+	Err    error
+	Coords []Coord
+	Detail string
+}
+
+func (e *FleetError) Error() string {
+	msg := e.Err.Error()
+	if len(e.Coords) > 0 {
+		msg = fmt.Sprintf("%s at %s", msg, strings.Join(FormatCoords(e.Coords), ", "))
+	}
+	if e.Detail != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Detail)
+	}
+	return msg
+}
+
+func (e *FleetError) Unwrap() error {
+	return e.Err
+}
+
+// Validates coordinates in the server format. See `ValidateFleet`.
+func ValidateCoords(coords []string) error {
+	cells, err := ParseCoords(coords)
+	if err != nil {
+		return &FleetError{Err: ErrInvalidCoord, Detail: err.Error()}
+	}
+	return ValidateFleet(cells)
+}
+
+// Checks that the cells form exactly the fleet described by `Fleet`: the right number of cells without duplicates,
+// every ship is a straight line, and no two ships touch each other, not even diagonally.
+func ValidateFleet(coords []Coord) error {
+	if len(coords) != FleetCells {
+		return &FleetError{Err: ErrFleetSize, Detail: fmt.Sprintf("expected %d, got %d", FleetCells, len(coords))}
+	}
+	seen := make(map[Coord]bool, len(coords))
+	for _, c := range coords {
+		if !c.Valid() {
+			return &FleetError{Err: ErrInvalidCoord, Detail: "outside of the board"}
+		}
+		if seen[c] {
+			return &FleetError{Err: ErrDuplicateCoord, Coords: []Coord{c}}
+		}
+		seen[c] = true
+	}
+
+	lengths := make([]int, 0, len(Fleet))
+	// Cells touching each other in any way form one cluster, so touching ships are checked within a cluster.
+	for _, cluster := range Ships(coords) {
+		if parts := sideConnected(cluster); len(parts) > 1 {
+			return &FleetError{Err: ErrShipsTouch, Coords: diagonalContacts(parts)}
+		}
+		if !cluster.straight() {
+			return &FleetError{Err: ErrShipShape, Coords: sortedCoords(cluster)}
+		}
+		lengths = append(lengths, len(cluster))
+	}
+	return checkComposition(lengths)
+}
+
+// Compares the lengths of the ships with the lengths in `Fleet`.
+func checkComposition(lengths []int) error {
+	want := make(map[int]int)
+	for _, l := range Fleet {
+		want[l]++
+	}
+	got := make(map[int]int)
+	for _, l := range lengths {
+		got[l]++
+	}
+	sizes := make([]int, 0)
+	for l := range want {
+		sizes = append(sizes, l)
+	}
+	for l := range got {
+		if _, ok := want[l]; !ok {
+			sizes = append(sizes, l)
+		}
+	}
+	slices.Sort(sizes)
+	slices.Reverse(sizes)
+	for _, l := range sizes {
+		if want[l] == 0 {
+			return &FleetError{Err: ErrFleetComposition, Detail: fmt.Sprintf("ships of length %d are not allowed", l)}
+		}
+		if want[l] != got[l] {
+			return &FleetError{
+				Err:    ErrFleetComposition,
+				Detail: fmt.Sprintf("expected %d ships of length %d, got %d", want[l], l, got[l]),
+			}
+		}
+	}
+	return nil
+}
+
+// Reports whether all the cells lie in a single row or column.
+// The cells are expected to be connected by their sides, so a straight ship has no gaps.
+func (s Ship) straight() bool {
+	sameX, sameY := true, true
+	for _, c := range s[1:] {
+		sameX = sameX && c.X == s[0].X
+		sameY = sameY && c.Y == s[0].Y
+	}
+	return sameX || sameY
+}
+
+// Splits the cells into groups connected by their sides only.
+func sideConnected(cells []Coord) [][]Coord {
+	parts := make([][]Coord, 0)
+	visited := make(map[Coord]bool)
+	for _, c := range cells {
+		if visited[c] {
+			continue
+		}
+		visited[c] = true
+		part := []Coord{c}
+		for i := 0; i < len(part); i++ {
+			for _, near := range part[i].Adjacent() {
+				if !visited[near] && slices.Contains(cells, near) {
+					visited[near] = true
+					part = append(part, near)
+				}
+			}
+		}
+		parts = append(parts, part)
+	}
+	return parts
+}
+
+// Returns the cells of the first group that touch any other group diagonally.
+func diagonalContacts(parts [][]Coord) []Coord {
+	out := make([]Coord, 0)
+	for _, c := range parts[0] {
+		for _, near := range c.Neighbours() {
+			for _, other := range parts[1:] {
+				if slices.Contains(other, near) && !slices.Contains(out, c) {
+					out = append(out, c)
+				}
+			}
+		}
+	}
+	return sortedCoords(out)
+}
+
+func sortedCoords(coords []Coord) []Coord {
+	out := slices.Clone(coords)
+	slices.SortFunc(out, func(a, b Coord) int {
+		if a.X != b.X {
+			return a.X - b.X
+		}
+		return a.Y - b.Y
+	})
+	return out
+}
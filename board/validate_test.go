@@ -0,0 +1,59 @@
+package board
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// Valid fleet with the ships in the columns A, C, E, G and I.
+var validFleet = []string{
+	"A1", "A2", "A3", "A4",
+	"C1", "C2", "C3",
+	"E1", "E2", "E3",
+	"G1", "G2",
+	"I1", "I2",
+	"A6", "A7",
+	"C6",
+	"E6",
+	"G6",
+	"I6",
+}
+
+// Returns the valid fleet without the given cells and with the added ones.
+func changedFleet(remove []string, add ...string) []string {
+	out := slices.DeleteFunc(slices.Clone(validFleet), func(c string) bool { return slices.Contains(remove, c) })
+	return append(out, add...)
+}
+
+func TestValidateCoords(t *testing.T) {
+	tests := []struct {
+		name   string
+		coords []string
+		want   error
+	}{
+		{"valid fleet", validFleet, nil},
+		{"missing cell", changedFleet([]string{"I6"}), ErrFleetSize},
+		{"no cells", nil, ErrFleetSize},
+		{"duplicated cell", changedFleet([]string{"I6"}, "A1"), ErrDuplicateCoord},
+		{"outside of the board", changedFleet([]string{"I6"}, "K1"), ErrInvalidCoord},
+		{"signed number", changedFleet([]string{"I6"}, "I+6"), ErrInvalidCoord},
+		{"ships touch diagonally", changedFleet([]string{"I6"}, "B5"), ErrShipsTouch},
+		{"bent ship", changedFleet([]string{"I6"}, "H2"), ErrShipShape},
+		{"wrong composition", changedFleet([]string{"C6", "E6"}, "J9", "J10"), ErrFleetComposition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCoords(tt.coords)
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("ValidateCoords() error = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.want) {
+				t.Errorf("ValidateCoords() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package engine
+
+import (
+	"battleship_client/api/client"
+	"errors"
+	"math/rand"
+	"time"
+)
+
+const (
+	// Delay after the first failed request, doubled after every next one.
+	backoffBase = time.Second
+	backoffMax  = time.Second * 30
+	// Part of the delay by which it is randomly shortened or lengthened, so clients do not retry in step.
+	backoffJitter = 0.25
+)
+
+// Exponential backoff of the requests that keep failing.
+type backoff struct {
+	failures int
+}
+
+// Counts the failed request and returns the time to wait before the next one.
+// The delay is never shorter than the time requested by the server.
+func (b *backoff) next(err error) time.Duration {
+	b.failures++
+	// The shift is limited, so the delay does not overflow after many failures.
+	d := min(backoffBase<<min(b.failures-1, 10), backoffMax)
+	d += time.Duration((rand.Float64()*2 - 1) * backoffJitter * float64(d))
+	var resErr *client.ResponseError
+	if errors.As(err, &resErr) && resErr.RetryAfter > d {
+		d = resErr.RetryAfter
+	}
+	return d
+}
+
+func (b *backoff) reset() {
+	b.failures = 0
+}
+
+// Reports whether the request may succeed if it is sent again later, e.g. when the server is overloaded.
+func isTransient(err error) bool {
+	var resErr *client.ResponseError
+	if !errors.As(err, &resErr) {
+		// The request did not reach the server at all.
+		return true
+	}
+	return errors.Is(err, client.ErrRateLimited) || errors.Is(err, client.ErrServer)
+}
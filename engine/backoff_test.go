@@ -0,0 +1,115 @@
+package engine
+
+import (
+	"battleship_client/api/client"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	rateLimited := responseError(t, http.StatusTooManyRequests, "5")
+	tests := []struct {
+		name string
+		errs []error
+		// Delay after the last error before the jitter is applied.
+		want time.Duration
+		// Lower limit of the delay, set when the server requested it.
+		wantMin time.Duration
+	}{
+		{name: "first failure", errs: []error{errors.New("refused")}, want: time.Second},
+		{name: "third failure", errs: []error{errors.New("refused"), errors.New("refused"), errors.New("refused")}, want: time.Second * 4},
+		{name: "many failures", errs: repeatErr(errors.New("refused"), 64), want: backoffMax},
+		{name: "longer delay requested by the server", errs: []error{rateLimited}, want: time.Second, wantMin: time.Second * 5},
+		{name: "shorter delay requested by the server", errs: repeatErr(rateLimited, 5), want: time.Second * 16},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b backoff
+			var d time.Duration
+			for _, err := range tt.errs {
+				d = b.next(err)
+			}
+			if b.failures != len(tt.errs) {
+				t.Errorf("failures = %d, want %d", b.failures, len(tt.errs))
+			}
+			low := time.Duration(float64(tt.want) * (1 - backoffJitter))
+			high := time.Duration(float64(tt.want) * (1 + backoffJitter))
+			if tt.wantMin > 0 {
+				low, high = tt.wantMin, max(tt.wantMin, high)
+			}
+			if d < low || d > high {
+				t.Errorf("delay = %s, want from %s to %s", d, low, high)
+			}
+		})
+	}
+}
+
+func TestBackoffJitterAndReset(t *testing.T) {
+	delays := make(map[time.Duration]bool)
+	for i := 0; i < 20; i++ {
+		var b backoff
+		delays[b.next(errors.New("refused"))] = true
+	}
+	if len(delays) == 1 {
+		t.Errorf("all the delays are the same, want them to be spread by the jitter")
+	}
+
+	var b backoff
+	for i := 0; i < 5; i++ {
+		b.next(errors.New("refused"))
+	}
+	b.reset()
+	if d := b.next(errors.New("refused")); d > time.Duration(float64(backoffBase)*(1+backoffJitter)) {
+		t.Errorf("delay after the reset = %s, want about %s", d, backoffBase)
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{errors.New("connection refused"), true},
+		{responseError(t, http.StatusTooManyRequests, ""), true},
+		{responseError(t, http.StatusServiceUnavailable, ""), true},
+		{responseError(t, http.StatusBadRequest, ""), false},
+		{responseError(t, http.StatusUnauthorized, ""), false},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.err), func(t *testing.T) {
+			if got := isTransient(tt.err); got != tt.want {
+				t.Errorf("isTransient(%v) = %t, want %t", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func repeatErr(err error, n int) []error {
+	errs := make([]error, n)
+	for i := range errs {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Returns the error of the status request answered by a server that always responds with the status code.
+// The Retry-After header is sent if it is not empty.
+func responseError(t *testing.T, statusCode int, retryAfter string) error {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if retryAfter != "" {
+			w.Header().Set("Retry-After", retryAfter)
+		}
+		w.WriteHeader(statusCode)
+	}))
+	defer srv.Close()
+	_, err := client.NewGameClient(client.Options{BaseURL: srv.URL, RetryMax: 0}).Status()
+	if err == nil {
+		t.Fatalf("status answered with %d did not fail", statusCode)
+	}
+	return err
+}
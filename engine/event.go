@@ -0,0 +1,67 @@
+package engine
+
+import "time"
+
+// Event emitted by the game session to its subscribers. It is one of the event types of this package.
+type Event interface {
+	event()
+}
+
+// Requests to the server that can fail during the game.
+const (
+	OpStatus = "status"
+	OpFire   = "fire"
+)
+
+// Emitted when the turn passes to the player or to the opponent, and for the first status of the game.
+type TurnChanged struct {
+	PlayerTurn bool
+}
+
+// Emitted for every status received during the player's turn.
+type TimerTick struct {
+	// Seconds left for the shot, as reported by the server.
+	Timer int
+}
+
+// Emitted when the opponent fired new shots.
+type OpponentShot struct {
+	// All the opponent's shots since the start of the game, not only the new ones.
+	Shots []string
+}
+
+// Emitted when the server responded to the player's shot.
+type ShotResult struct {
+	Coord string
+	// One of the results of the shot, e.g. `board.ResultHit`.
+	Result string
+}
+
+// Emitted once when the game is over. It is the last event of the session.
+type GameEnded struct {
+	// One of the outcomes of the game history, e.g. `history.OutcomeWin`.
+	Outcome string
+}
+
+// Emitted when a request to the server failed. The game goes on unless the error is followed by `GameEnded`.
+type Error struct {
+	// Request that failed, e.g. `OpStatus`.
+	Op  string
+	Err error
+}
+
+// Emitted after every request for the status, so the front-end can display the health of the connection.
+type Connection struct {
+	// Number of requests that failed in a row, 0 if the last one succeeded.
+	Failures int
+	// Time the last status was received.
+	LastPoll time.Time
+}
+
+func (TurnChanged) event()  {}
+func (TimerTick) event()    {}
+func (OpponentShot) event() {}
+func (ShotResult) event()   {}
+func (GameEnded) event()    {}
+func (Error) event()        {}
+func (Connection) event()   {}
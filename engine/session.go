@@ -0,0 +1,274 @@
+// Package engine plays the game on the server independently of the front-end.
+// The game session polls the status of the game and reports every change as an event to its subscribers.
+package engine
+
+import (
+	"battleship_client/api/client"
+	"battleship_client/board"
+	"battleship_client/history"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Times between the requests for the status. The status is polled faster when the turn is likely to change soon.
+const (
+	// While waiting for the opponent to join.
+	lobbyPoll = time.Second * 2
+	// During the turn of either player.
+	turnPoll = time.Second
+	// At the end of the turn of either player, when the turn passes as the time runs out.
+	fastPoll = time.Millisecond * 500
+	// Seconds left in the turn below which the status is polled fast.
+	lowTimer = 3
+)
+
+const (
+	// Time after which the waiting game is refreshed, so the server does not remove it.
+	refreshInterval = time.Second * 10
+	// Number of events a subscriber can fall behind before the session waits for it.
+	eventBuffer = 16
+)
+
+// Game in progress on the server. The session is the only owner of the game status,
+// the front-ends learn about the changes from the events and act through `Fire` and `Abandon`.
+type GameSession struct {
+	client client.GameClient
+
+	mu          sync.RWMutex
+	subscribers []chan Event
+	closed      bool
+
+	abandonOnce sync.Once
+	abandoned   chan struct{}
+	// Wakes up `Run` to poll the status right away, e.g. after the player missed and the turn passed.
+	wake chan struct{}
+	// Time of the last status received by `WaitForStart`.
+	started time.Time
+}
+
+func NewGameSession(apiClient client.GameClient) *GameSession {
+	return &GameSession{
+		client:    apiClient,
+		abandoned: make(chan struct{}),
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+// Returns the channel that receives the events of the session. It has to be called before `Run`.
+// The subscriber has to receive the events until the channel is closed after the game ends.
+func (s *GameSession) Subscribe() <-chan Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ch := make(chan Event, eventBuffer)
+	s.subscribers = append(s.subscribers, ch)
+	return ch
+}
+
+// Fetches the status until the game starts, and refreshes the game session every 10 seconds.
+// Requests that fail for a transient reason, e.g. rate limiting, are retried with a backoff.
+// Returns the status of the started game.
+func (s *GameSession) WaitForStart(ctx context.Context) (statusRes client.StatusResponse, err error) {
+	var retry backoff
+	lastRefresh := time.Now()
+	for {
+		err = nil
+		if time.Since(lastRefresh) >= refreshInterval {
+			err = s.client.RefreshContext(ctx)
+			if err == nil {
+				lastRefresh = time.Now()
+			} else if !isTransient(err) {
+				return statusRes, fmt.Errorf("failed to refresh game session: %w", err)
+			}
+		}
+		if err == nil {
+			statusRes, err = s.client.StatusContext(ctx)
+			if err != nil && !isTransient(err) {
+				return statusRes, fmt.Errorf("failed to get game status: %w", err)
+			}
+		}
+		delay := lobbyPoll
+		switch {
+		case err != nil:
+			delay = retry.next(err)
+		case statusRes.Status == "game_in_progress":
+			s.started = time.Now()
+			return statusRes, nil
+		default:
+			retry.reset()
+		}
+		if !sleep(ctx, delay, nil) {
+			return statusRes, ctx.Err()
+		}
+	}
+}
+
+// Polls the status of the started game and emits its changes until the game is over or abandoned.
+// Emits `GameEnded` unless the context is done first, and then closes the channels of the subscribers.
+func (s *GameSession) Run(ctx context.Context) {
+	defer s.close()
+	// Stops the request in flight when the game is abandoned.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-s.abandoned:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	var retry backoff
+	lastPoll := s.started
+	oppShotCount := 0
+	turnKnown, playerTurn := false, false
+	for {
+		statusRes, err := s.client.StatusContext(ctx)
+		if ctx.Err() != nil {
+			s.endAbandoned()
+			return
+		}
+		if err != nil {
+			delay := retry.next(err)
+			s.emit(ctx, Error{Op: OpStatus, Err: err})
+			s.emit(ctx, Connection{Failures: retry.failures, LastPoll: lastPoll})
+			if IsSessionLost(err) {
+				s.emit(ctx, GameEnded{Outcome: history.OutcomeSessionLost})
+				return
+			}
+			if !sleep(ctx, delay, nil) {
+				s.endAbandoned()
+				return
+			}
+			continue
+		}
+		retry.reset()
+		lastPoll = time.Now()
+		s.emit(ctx, Connection{LastPoll: lastPoll})
+		if size := len(statusRes.OpponentShots); size != oppShotCount {
+			s.emit(ctx, OpponentShot{Shots: statusRes.OpponentShots})
+			oppShotCount = size
+		}
+		if statusRes.Status == "ended" {
+			outcome := history.OutcomeWin
+			if statusRes.LastGameStatus == "lose" {
+				outcome = history.OutcomeLose
+			}
+			s.emit(ctx, GameEnded{Outcome: outcome})
+			return
+		}
+		if !turnKnown || statusRes.ShouldFire != playerTurn {
+			turnKnown, playerTurn = true, statusRes.ShouldFire
+			s.emit(ctx, TurnChanged{PlayerTurn: playerTurn})
+		}
+		if statusRes.ShouldFire {
+			s.emit(ctx, TimerTick{Timer: statusRes.Timer})
+		}
+		if !sleep(ctx, pollDelay(statusRes), s.wake) {
+			s.endAbandoned()
+			return
+		}
+	}
+}
+
+// Returns the time to wait before the next status. It is shorter when the turn is about to pass, because its time runs out.
+// The opponent's turn also passes when they miss, which cannot be foreseen, so it is polled like the player's turn until then.
+func pollDelay(statusRes client.StatusResponse) time.Duration {
+	if statusRes.Timer <= lowTimer {
+		return fastPoll
+	}
+	return turnPoll
+}
+
+// Fires at the coordinate and emits the result of the shot.
+// If the request fails, the error is emitted as well as returned.
+func (s *GameSession) Fire(ctx context.Context, coord string) error {
+	result, err := s.client.FireContext(ctx, coord)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err != nil {
+		s.emit(ctx, Error{Op: OpFire, Err: err})
+		return err
+	}
+	s.emit(ctx, ShotResult{Coord: coord, Result: result})
+	// The turn passes to the opponent after a miss, which the next status shows.
+	if result == board.ResultMiss {
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Abandons the game on the server and stops `Run`, which emits the abandoned outcome if the game was not over yet.
+func (s *GameSession) Abandon() error {
+	s.abandonOnce.Do(func() { close(s.abandoned) })
+	return s.client.Abandon()
+}
+
+// Emits the abandoned outcome if `Run` was stopped by `Abandon` rather than by its own context.
+func (s *GameSession) endAbandoned() {
+	select {
+	case <-s.abandoned:
+	default:
+		return
+	}
+	// The run context is already cancelled, so the event is sent to the subscribers without it.
+	s.emit(context.Background(), GameEnded{Outcome: history.OutcomeAbandoned})
+}
+
+// Sends the event to all the subscribers. Gives up on a subscriber that does not receive it before the context is done.
+func (s *GameSession) emit(ctx context.Context, ev Event) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return
+	}
+	for _, ch := range s.subscribers {
+		select {
+		case ch <- ev:
+		case <-ctx.Done():
+		}
+	}
+}
+
+func (s *GameSession) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	for _, ch := range s.subscribers {
+		close(ch)
+	}
+}
+
+// Waits for the given time, or until a value is received from `wake`. Returns false if the context is done first.
+func sleep(ctx context.Context, d time.Duration, wake <-chan struct{}) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+	case <-wake:
+	}
+	return true
+}
+
+// Reports whether the error means that the game token is no longer valid and the game cannot be continued.
+func IsSessionLost(err error) bool {
+	return errors.Is(err, client.ErrUnauthorized) || errors.Is(err, client.ErrGameNotFound)
+}
+
+// Returns the time to wait before the next request after the error.
+// Uses the time requested by the server if it sent one.
+func RetryDelay(err error) time.Duration {
+	var resErr *client.ResponseError
+	if errors.As(err, &resErr) && resErr.RetryAfter > 0 {
+		return resErr.RetryAfter
+	}
+	return time.Second
+}
@@ -0,0 +1,227 @@
+package engine
+
+import (
+	"battleship_client/api/client"
+	"battleship_client/api/server"
+	"battleship_client/board"
+	"battleship_client/history"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPollDelay(t *testing.T) {
+	tests := []struct {
+		name   string
+		status client.StatusResponse
+		want   time.Duration
+	}{
+		{name: "player's turn", status: client.StatusResponse{ShouldFire: true, Timer: 45}, want: turnPoll},
+		{name: "end of the player's turn", status: client.StatusResponse{ShouldFire: true, Timer: lowTimer}, want: fastPoll},
+		{name: "opponent's turn", status: client.StatusResponse{Timer: 45}, want: turnPoll},
+		{name: "end of the opponent's turn", status: client.StatusResponse{Timer: 1}, want: fastPoll},
+		{name: "opponent's time ran out", status: client.StatusResponse{Timer: 0}, want: fastPoll},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pollDelay(tt.status); got != tt.want {
+				t.Errorf("pollDelay(%+v) = %s, want %s", tt.status, got, tt.want)
+			}
+		})
+	}
+}
+
+// Events received by the subscriber of the session, collected on their own goroutine.
+type eventLog struct {
+	mu     sync.Mutex
+	events []Event
+	// Closed when the session closed the channel of the subscriber.
+	done chan struct{}
+}
+
+func collect(events <-chan Event) *eventLog {
+	log := &eventLog{done: make(chan struct{})}
+	go func() {
+		for ev := range events {
+			log.mu.Lock()
+			log.events = append(log.events, ev)
+			log.mu.Unlock()
+		}
+		close(log.done)
+	}()
+	return log
+}
+
+func (l *eventLog) Events() []Event {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]Event(nil), l.events...)
+}
+
+// Waits until an event matching the condition is received, or fails the test after a few seconds.
+func (l *eventLog) waitFor(t *testing.T, what string, match func(Event) bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second * 5)
+	for time.Now().Before(deadline) {
+		for _, ev := range l.Events() {
+			if match(ev) {
+				return
+			}
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	t.Fatalf("timed out waiting for %s, events: %+v", what, l.Events())
+}
+
+func (l *eventLog) wait(t *testing.T) []Event {
+	t.Helper()
+	select {
+	case <-l.done:
+	case <-time.After(time.Second * 5):
+		t.Fatalf("session did not close the events, events: %+v", l.Events())
+	}
+	return l.Events()
+}
+
+// Plays against the bot of the stand-in server until the bot fires, and then abandons the game.
+func TestRunEvents(t *testing.T) {
+	opts := server.DefaultOptions()
+	opts.BotDelay = 0
+	opts.Seed = 1
+	srv := httptest.NewServer(server.New(opts))
+	t.Cleanup(srv.Close)
+	apiClient, err := client.InitGame(client.GameSettings{Nick: "alice", AgainstBot: true}, client.Options{BaseURL: srv.URL + "/api"})
+	if err != nil {
+		t.Fatalf("InitGame() error: %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	game := NewGameSession(apiClient)
+	if _, err := game.WaitForStart(ctx); err != nil {
+		t.Fatalf("WaitForStart() error: %s", err)
+	}
+	log := collect(game.Subscribe())
+	go game.Run(ctx)
+	log.waitFor(t, "the player's turn", func(ev Event) bool { return ev == TurnChanged{PlayerTurn: true} })
+
+	// The bot fires right after the player misses.
+	fired := make([]string, 0)
+	for _, c := range board.AllCoords() {
+		if err := game.Fire(ctx, c.String()); err != nil {
+			t.Fatalf("Fire(%s) error: %s", c, err)
+		}
+		fired = append(fired, c.String())
+		missed := false
+		log.waitFor(t, "the result of the shot", func(ev Event) bool {
+			res, ok := ev.(ShotResult)
+			missed = ok && res.Result == board.ResultMiss
+			return ok && res.Coord == c.String()
+		})
+		if missed {
+			break
+		}
+	}
+	log.waitFor(t, "the shot of the bot", func(ev Event) bool {
+		shot, ok := ev.(OpponentShot)
+		return ok && len(shot.Shots) > 0
+	})
+	game.Abandon()
+	events := log.wait(t)
+
+	if first, ok := events[0].(Connection); !ok || first.Failures != 0 || first.LastPoll.IsZero() {
+		t.Errorf("first event = %+v, want a successful poll", events[0])
+	}
+	if last := events[len(events)-1]; last != (GameEnded{Outcome: history.OutcomeAbandoned}) {
+		t.Errorf("last event = %+v, want the abandoned game", last)
+	}
+	results := make([]string, 0)
+	turn := false
+	for _, ev := range events {
+		switch ev := ev.(type) {
+		case ShotResult:
+			results = append(results, ev.Coord)
+		case TurnChanged:
+			turn = ev.PlayerTurn
+		case TimerTick:
+			if !turn {
+				t.Errorf("timer %d emitted during the opponent's turn", ev.Timer)
+			}
+		case Error:
+			t.Errorf("error event: %s", ev.Err)
+		}
+	}
+	if len(results) != len(fired) {
+		t.Errorf("shot results = %v, want the results of %v", results, fired)
+	}
+}
+
+func TestRunStatusErrors(t *testing.T) {
+	ended := client.StatusResponse{Status: "ended", LastGameStatus: "win"}
+	tests := []struct {
+		name string
+		// Responses of the server in order, the last one is repeated.
+		statuses []int
+		want     []Event
+	}{
+		{
+			name:     "session lost",
+			statuses: []int{http.StatusUnauthorized},
+			want: []Event{
+				Error{Op: OpStatus},
+				Connection{Failures: 1},
+				GameEnded{Outcome: history.OutcomeSessionLost},
+			},
+		},
+		{
+			name:     "server recovers",
+			statuses: []int{http.StatusServiceUnavailable, http.StatusOK},
+			want: []Event{
+				Error{Op: OpStatus},
+				Connection{Failures: 1},
+				Connection{},
+				GameEnded{Outcome: history.OutcomeWin},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			requests := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				status := tt.statuses[min(requests, len(tt.statuses)-1)]
+				requests++
+				mu.Unlock()
+				w.WriteHeader(status)
+				json.NewEncoder(w).Encode(ended)
+			}))
+			t.Cleanup(srv.Close)
+
+			game := NewGameSession(client.NewGameClient(client.Options{BaseURL: srv.URL, RetryMax: 0}))
+			log := collect(game.Subscribe())
+			go game.Run(context.Background())
+			events := log.wait(t)
+
+			if len(events) != len(tt.want) {
+				t.Fatalf("events = %+v, want %+v", events, tt.want)
+			}
+			for i, ev := range events {
+				// Only the kinds of the errors and the counts of the failures are compared.
+				switch ev := ev.(type) {
+				case Error:
+					events[i] = Error{Op: ev.Op}
+				case Connection:
+					events[i] = Connection{Failures: ev.Failures}
+				}
+				if events[i] != tt.want[i] {
+					t.Errorf("event %d = %+v, want %+v", i, events[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
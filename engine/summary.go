@@ -0,0 +1,71 @@
+package engine
+
+import (
+	"battleship_client/board"
+	"slices"
+	"time"
+)
+
+// Statistics of a finished game, displayed to the player after it ends.
+type Summary struct {
+	// One of the outcomes of the game history, e.g. `history.OutcomeWin`.
+	Outcome  string
+	Nick     string
+	Opponent string
+	// Number of the player's shots and of those that hit a ship, sunk ones included.
+	Shots int
+	Hits  int
+	// Longest series of the player's hits in a row.
+	LongestStreak int
+	// Number of the player's turns in which they fired.
+	Turns    int
+	Duration time.Duration
+	// The player's board with the opponent's shots and all the player's ships, including the ones that were not hit.
+	PlayerBoard board.Grid
+	// The opponent's board as known from the player's shots.
+	OpponentBoard board.Grid
+}
+
+// Counts the shots, hits, the longest streak and the turns from the results of the player's shots in the order they were fired.
+// A turn ends with a miss, so the hits after the last miss make another turn.
+func (s *Summary) CountShots(results []string) {
+	streak := 0
+	for _, result := range results {
+		s.Shots++
+		if result == board.ResultMiss {
+			s.Turns++
+			streak = 0
+			continue
+		}
+		s.Hits++
+		streak++
+		s.LongestStreak = max(s.LongestStreak, streak)
+	}
+	if streak > 0 {
+		s.Turns++
+	}
+}
+
+// Returns the player's board with the ships and the opponent's shots. The ships that were hit on all their tiles are marked as sunk.
+func PlayerBoard(ships []board.Coord, oppShots []board.Coord) board.Grid {
+	grid := board.Grid{}
+	for _, c := range ships {
+		grid.Set(c, board.Occupied)
+	}
+	for _, c := range oppShots {
+		if slices.Contains(ships, c) {
+			grid.Set(c, board.Hit)
+		} else {
+			grid.Set(c, board.Miss)
+		}
+	}
+	for _, ship := range grid.Ships() {
+		if slices.ContainsFunc(ship, func(c board.Coord) bool { return grid.At(c) != board.Hit }) {
+			continue
+		}
+		for _, c := range ship {
+			grid.Set(c, board.Sunk)
+		}
+	}
+	return grid
+}
@@ -0,0 +1,81 @@
+package engine
+
+import (
+	"battleship_client/board"
+	"testing"
+)
+
+func TestCountShots(t *testing.T) {
+	const (
+		hit  = board.ResultHit
+		miss = board.ResultMiss
+		sunk = board.ResultSunk
+	)
+	tests := []struct {
+		name    string
+		results []string
+		want    Summary
+	}{
+		{name: "no shots", want: Summary{}},
+		{name: "single miss", results: []string{miss}, want: Summary{Shots: 1, Turns: 1}},
+		{name: "hits until the end", results: []string{hit, sunk}, want: Summary{Shots: 2, Hits: 2, LongestStreak: 2, Turns: 1}},
+		{
+			name:    "hits ending with a miss",
+			results: []string{hit, hit, miss, miss, sunk, miss},
+			want:    Summary{Shots: 6, Hits: 3, LongestStreak: 2, Turns: 3},
+		},
+		{
+			name:    "longest streak in the middle",
+			results: []string{miss, hit, sunk, hit, sunk, miss, hit},
+			want:    Summary{Shots: 7, Hits: 5, LongestStreak: 4, Turns: 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Summary{}
+			got.CountShots(tt.results)
+			if got != tt.want {
+				t.Errorf("CountShots(%v) = %d shots, %d hits, streak %d, %d turns, want %d, %d, %d, %d", tt.results,
+					got.Shots, got.Hits, got.LongestStreak, got.Turns, tt.want.Shots, tt.want.Hits, tt.want.LongestStreak, tt.want.Turns)
+			}
+		})
+	}
+}
+
+func TestPlayerBoard(t *testing.T) {
+	// A sunk two-tile ship at A1-A2, a hit three-tile ship at E5-E7 and a single-tile ship at C1 that was not shot.
+	ships := coords(t, "A1", "A2", "C1", "E5", "E6", "E7")
+	grid := PlayerBoard(ships, coords(t, "A1", "J10", "A2", "E5", "B5"))
+	tests := []struct {
+		coord string
+		want  board.Cell
+	}{
+		{coord: "A1", want: board.Sunk},
+		{coord: "A2", want: board.Sunk},
+		{coord: "E5", want: board.Hit},
+		// The ships that were not hit are revealed.
+		{coord: "E6", want: board.Occupied},
+		{coord: "E7", want: board.Occupied},
+		{coord: "C1", want: board.Occupied},
+		{coord: "J10", want: board.Miss},
+		{coord: "B5", want: board.Miss},
+		// Only the cells that were shot are marked as missed, also around the sunk ship.
+		{coord: "B1", want: board.Empty},
+		{coord: "A3", want: board.Empty},
+	}
+	for _, tt := range tests {
+		c := coords(t, tt.coord)[0]
+		if got := grid.At(c); got != tt.want {
+			t.Errorf("cell %s = %v, want %v", tt.coord, got, tt.want)
+		}
+	}
+}
+
+func coords(t *testing.T, s ...string) []board.Coord {
+	t.Helper()
+	out, err := board.ParseCoords(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
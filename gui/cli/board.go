@@ -1,17 +1,21 @@
 package cli
 
 import (
+	"battleship_client/board"
 	"fmt"
-	"strconv"
 
 	gui "github.com/RostKoff/warships-gui/v2"
 )
 
 type GameBoard struct {
-	Nick   *gui.Text
-	Desc   *gui.TextField
-	Board  *gui.Board
-	states [10][10]gui.State
+	Nick  *gui.Text
+	Desc  *gui.TextField
+	Board *gui.Board
+	grid  board.Grid
+	// Cell emphasised on top of its state, e.g. the recommended target.
+	highlight *board.Coord
+	// Cell the keyboard cursor is on. Displayed like a ship, which never appears on the opponent's board.
+	cursor *board.Coord
 }
 
 func InitGameBoard(x int, y int, cfg *gui.BoardConfig) *GameBoard {
@@ -19,62 +23,94 @@ func InitGameBoard(x int, y int, cfg *gui.BoardConfig) *GameBoard {
 	b.Board = gui.NewBoard(x, y, cfg)
 	b.Nick = gui.NewText(x, y+22, "abobas", nil)
 	b.Desc = gui.NewTextField(x, y+23, 42, 15, nil)
-	b.Board.SetStates(b.states)
+	b.redraw()
 	return &b
 }
 
-func (b *GameBoard) UpdateState(coords string, state gui.State) error {
-	c, err := ConvertCoords(coords)
+func (b *GameBoard) UpdateState(coords string, cell board.Cell) error {
+	c, err := board.ParseCoord(coords)
 	if err != nil {
 		return fmt.Errorf("failed to convert coords: %w", err)
 	}
-	b.states[c[0]][c[1]] = state
-	b.Board.SetStates(b.states)
+	b.SetCell(c, cell)
 	return nil
 }
 
-func (b *GameBoard) UpdateStateWithDigitCoords(letterCoord int, numCoord int, state gui.State) error {
-	if letterCoord < 0 || letterCoord > 9 {
-		return fmt.Errorf("letter coord is out of bounce")
-	}
-	if numCoord < 0 || numCoord > 9 {
-		return fmt.Errorf("number coord is out of bounce")
-	}
-	b.states[letterCoord][numCoord] = state
-	b.Board.SetStates(b.states)
-	return nil
+func (b *GameBoard) SetCell(c board.Coord, cell board.Cell) {
+	b.grid.Set(c, cell)
+	b.redraw()
 }
 
-func ConvertCoords(coords string) ([2]int, error) {
-	values := [2]int{}
-	if length := len(coords); length < 2 || length > 3 {
-		return values, fmt.Errorf("bad format")
-	}
-	letterCoord := int(coords[0]) - 65
-	if letterCoord < 0 || letterCoord > 9 {
-		return values, fmt.Errorf("letter coord is out of bounce")
+// Marks the ship containing the hit cell as sunk and the cells around it as missed. Returns the cells of the sunk ship.
+func (b *GameBoard) MarkSunk(c board.Coord) board.Ship {
+	ship := b.grid.MarkSunk(c)
+	b.redraw()
+	return ship
+}
+
+// Marks the result of the shot at the cell, see `board.Grid.ApplyResult`.
+func (b *GameBoard) ApplyResult(c board.Coord, result string) (board.Ship, error) {
+	ship, err := b.grid.ApplyResult(c, result)
+	b.redraw()
+	return ship, err
+}
+
+// Replaces the states of all cells of the board.
+func (b *GameBoard) SetGrid(g board.Grid) {
+	b.grid = g
+	b.redraw()
+}
+
+// Returns a copy of the states of all cells of the board.
+func (b *GameBoard) Grid() board.Grid {
+	return b.grid
+}
+
+// Emphasises the cell on the board without changing its state. Only one cell can be highlighted at a time.
+func (b *GameBoard) SetHighlight(c board.Coord) {
+	b.highlight = &c
+	b.redraw()
+}
+
+func (b *GameBoard) ClearHighlight() {
+	b.highlight = nil
+	b.redraw()
+}
+
+// Shows the keyboard cursor on the cell. The cursor is only visible on empty cells.
+func (b *GameBoard) SetCursor(c board.Coord) {
+	b.cursor = &c
+	b.redraw()
+}
+
+func (b *GameBoard) ClearCursor() {
+	b.cursor = nil
+	b.redraw()
+}
+
+func (b *GameBoard) redraw() {
+	states := [10][10]gui.State{}
+	for _, c := range board.AllCoords() {
+		states[c.X][c.Y] = guiState(b.grid.At(c))
 	}
-	numCoord, err := strconv.Atoi(coords[1:])
-	if err != nil {
-		return values, fmt.Errorf("failed to convert number coord to integer: %w", err)
+	if h := b.highlight; h != nil && b.grid.At(*h) == board.Empty {
+		states[h.X][h.Y] = gui.Emphasis
 	}
-	numCoord--
-	if numCoord < 0 || numCoord > 9 {
-		return values, fmt.Errorf("number coord is out of bounce")
+	if c := b.cursor; c != nil && b.grid.At(*c) == board.Empty {
+		states[c.X][c.Y] = gui.Ship
 	}
-	values[0] = letterCoord
-	values[1] = numCoord
-	return values, nil
+	b.Board.SetStates(states)
 }
 
-func ConvertToString(lCoord, nCoord int) (string, error) {
-	if lCoord < 0 || lCoord > 9 {
-		return "", fmt.Errorf("letter coord is out of bounce")
-	}
-	if nCoord < 0 || nCoord > 9 {
-		return "", fmt.Errorf("number coord is out of bounce")
+// Converts the state of the cell into the state displayed on the board.
+func guiState(cell board.Cell) gui.State {
+	switch cell {
+	case board.Occupied:
+		return gui.Ship
+	case board.Hit, board.Sunk:
+		return gui.Hit
+	case board.Miss:
+		return gui.Miss
 	}
-	lCoord += 65
-	nCoord++
-	return fmt.Sprintf("%c%d", lCoord, nCoord), nil
+	return gui.Empty
 }
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"battleship_client/board"
+	"fmt"
+	"slices"
+	"strings"
+
+	gui "github.com/RostKoff/warships-gui/v2"
+)
+
+// List of the ships of a fleet in which the sunk ones are crossed out.
+type FleetPanel struct {
+	title     *gui.Text
+	titleText string
+	ships     []*gui.Text
+	// Lengths of the crossed out ships, in the order of `board.Fleet`.
+	sunk []int
+}
+
+// Creates the panel with all the ships of the fleet afloat, one ship per line below the title.
+func NewFleetPanel(x int, y int, title string) *FleetPanel {
+	p := FleetPanel{
+		title:     gui.NewText(x, y, "", nil),
+		titleText: title,
+		ships:     make([]*gui.Text, len(board.Fleet)),
+	}
+	for i := range board.Fleet {
+		p.ships[i] = gui.NewText(x, y+2+i, "", nil)
+	}
+	p.SetSunk(nil)
+	return &p
+}
+
+func (p *FleetPanel) Drawables() []gui.Drawable {
+	out := []gui.Drawable{p.title}
+	for _, ship := range p.ships {
+		out = append(out, ship)
+	}
+	return out
+}
+
+// Crosses out the ships with the given lengths and displays the other ones as afloat.
+func (p *FleetPanel) SetSunk(sunk []int) {
+	sunk = slices.Clone(sunk)
+	p.sunk = p.sunk[:0]
+	afloat := len(board.Fleet)
+	for i, length := range board.Fleet {
+		text := p.ships[i]
+		if j := slices.Index(sunk, length); j >= 0 {
+			sunk = slices.Delete(sunk, j, j+1)
+			text.SetText(fmt.Sprintf("%-5s sunk", strings.Repeat("x", length)))
+			text.SetFgColor(gui.Red)
+			p.sunk = append(p.sunk, length)
+			afloat--
+			continue
+		}
+		text.SetText(strings.Repeat("#", length))
+		text.SetFgColor(gui.White)
+	}
+	p.title.SetText(fmt.Sprintf("%s: %d/%d afloat", p.titleText, afloat, len(board.Fleet)))
+}
+
+// Returns the lengths of the crossed out ships, in the order of `board.Fleet`.
+func (p *FleetPanel) Sunk() []int {
+	return slices.Clone(p.sunk)
+}
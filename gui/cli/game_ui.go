@@ -1,19 +1,39 @@
 package cli
 
 import (
+	"battleship_client/ai"
+	"battleship_client/board"
 	"context"
 	"fmt"
 	"slices"
+	"sync"
+	"time"
 
 	gui "github.com/RostKoff/warships-gui/v2"
 )
 
 const (
 	AbandonOpt = "abandon"
+	HintOpt    = "hint"
+)
+
+// Number of failed requests in a row after which the connection is displayed as lost.
+const connectionLostFailures = 3
+
+const (
+	// Time left in the turn below which the countdown is displayed in orange.
+	countdownWarning = time.Second * 10
+	// Time left in the turn below which the countdown flashes red.
+	countdownCritical = time.Second * 5
+	// Time between the changes of the flashing countdown.
+	countdownFlash = time.Millisecond * 500
 )
 
 type GameUI struct {
-	Controller   *gui.GUI
+	Controller *gui.GUI
+	// Guards the boards, the cursor, the hint and the counts of the shots. The boards are updated with the events of the game
+	// while the player chooses the next shot, toggles the hint and the autoplay reads them, each on its own goroutine.
+	mu           sync.Mutex
 	PBoard       *GameBoard
 	OppBoard     *GameBoard
 	EndText      *gui.Text
@@ -21,17 +41,38 @@ type GameUI struct {
 	ErrorText    *gui.Text
 	Timer        *gui.Text
 	abandonBtn   *gui.Button
-	abandonArea  *gui.HandleArea
+	hintBtn      *gui.Button
+	btnArea      *gui.HandleArea
 	accuracyText *gui.Text
+	hintText     *gui.Text
+	hintOn       bool
 	hit          float64
 	miss         float64
+	// Keys typed into the input move the cursor on the opponent's board and fire.
+	keyInput    *gui.TextField
+	keyHelpText *gui.Text
+	cursor      KeyCursor
+	// Ships of both fleets that are still afloat.
+	oppFleet *FleetPanel
+	pFleet   *FleetPanel
+	waitText *gui.Text
+	// Health of the connection to the server and the time of the last status.
+	connText *gui.Text
 }
 
-func InitGameUI(controller *gui.GUI) *GameUI {
+// Creates the elements of the game screen without drawing them, so the waiting message can be displayed first.
+func NewGameUI(controller *gui.GUI) *GameUI {
 	abandonCfg := gui.NewButtonConfig()
 	abandonCfg.BgColor = gui.Red
 	abandonBtn := gui.NewButton(79, 1, "Abandon game", abandonCfg)
-	abandonArea := gui.NewHandleArea(map[string]gui.Physical{AbandonOpt: abandonBtn})
+	w, _ := abandonBtn.Size()
+	hintCfg := gui.NewButtonConfig()
+	hintCfg.BgColor = gui.Blue
+	hintBtn := gui.NewButton(80+w, 1, "Show hint", hintCfg)
+	btnArea := gui.NewHandleArea(map[string]gui.Physical{AbandonOpt: abandonBtn, HintOpt: hintBtn})
+	keyCfg := gui.NewTextFieldConfig()
+	keyCfg.UnfilledChar = '_'
+	keyCfg.InputOn = true
 	ui := GameUI{
 		Controller:   controller,
 		PBoard:       InitGameBoard(1, 5, nil),
@@ -42,12 +83,30 @@ func InitGameUI(controller *gui.GUI) *GameUI {
 		Timer:        gui.NewText(1, 3, "", nil),
 		ErrorText:    gui.NewText(50, 3, "", nil),
 		abandonBtn:   abandonBtn,
-		abandonArea:  abandonArea,
+		hintBtn:      hintBtn,
+		btnArea:      btnArea,
+		hintText:     gui.NewText(20, 3, "", nil),
+		keyInput:     gui.NewTextField(96, 35, 20, 1, keyCfg),
+		keyHelpText:  gui.NewText(96, 31, keyHelp+",\nspace: fire\n"+keyInputNote, nil),
+		oppFleet:     NewFleetPanel(96, 5, "Enemy fleet"),
+		pFleet:       NewFleetPanel(96, 18, "Your fleet"),
+		waitText:     gui.NewText(1, 1, "Waiting for game to start...", nil),
+		connText:     gui.NewText(96, 37, "", nil),
 	}
 
 	ui.ErrorText.SetBgColor(gui.Red)
 	ui.ErrorText.SetFgColor(gui.White)
+	return &ui
+}
 
+// Displays the message that the game has not started yet.
+func (ui *GameUI) ShowWaiting() {
+	ui.Controller.Draw(ui.waitText)
+}
+
+// Hides the waiting message and draws all the elements of the game screen.
+func (ui *GameUI) Show() {
+	ui.Controller.Remove(ui.waitText)
 	drawables := []gui.Drawable{
 		ui.PBoard.Board,
 		ui.PBoard.Nick,
@@ -59,105 +118,147 @@ func InitGameUI(controller *gui.GUI) *GameUI {
 		ui.TurnText,
 		ui.Timer,
 		ui.ErrorText,
-		ui.abandonArea,
+		ui.btnArea,
 		ui.abandonBtn,
+		ui.hintBtn,
 		ui.accuracyText,
+		ui.hintText,
+		ui.keyInput,
+		ui.keyHelpText,
+		ui.connText,
 	}
+	drawables = append(drawables, ui.oppFleet.Drawables()...)
+	drawables = append(drawables, ui.pFleet.Drawables()...)
 	for _, drawable := range drawables {
 		ui.Controller.Draw(drawable)
 	}
-	return &ui
 }
 
-func (ui *GameUI) HandleOppShots(pShips []string, oppShots []string) error {
-	pShipsCopy := make([]string, len(pShips))
-	copy(pShipsCopy, pShips)
+func (ui *GameUI) Log(format string, args ...any) {
+	ui.Controller.Log(format, args...)
+}
 
-	for _, shot := range oppShots {
-		state := gui.Miss
-		for n, ship := range pShipsCopy {
-			if shot == ship {
-				state = gui.Hit
-				pShipsCopy = append(pShipsCopy[:n], pShipsCopy[n+1:]...)
-				break
-			}
+// Marks the player's ships on their board.
+func (ui *GameUI) DrawShips(coords []string) error {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+	for _, coord := range coords {
+		if err := ui.PBoard.UpdateState(coord, board.Occupied); err != nil {
+			return err
 		}
-		err := ui.PBoard.UpdateState(shot, state)
+	}
+	return nil
+}
+
+func (ui *GameUI) HandleOppShots(pShips []string, oppShots []string) error {
+	ships, err := board.ParseCoords(pShips)
+	if err != nil {
+		return fmt.Errorf("failed to convert ship coords: %w", err)
+	}
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+	for _, shot := range oppShots {
+		c, err := board.ParseCoord(shot)
 		if err != nil {
-			return fmt.Errorf("failed to update state: %w", err)
+			return fmt.Errorf("failed to convert coords: %w", err)
 		}
+		cell := board.Miss
+		if slices.Contains(ships, c) {
+			cell = board.Hit
+		}
+		ui.PBoard.SetCell(c, cell)
 	}
+	ui.updateFleets()
 	return nil
 }
 
 func (ui *GameUI) HandlePShot(fireResponse string, coord string) error {
+	c, err := board.ParseCoord(coord)
+	if err != nil {
+		return fmt.Errorf("failed to convert coords: %w", err)
+	}
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+	// Fills the cells around the sunken ship with the value "missed".
+	if _, err := ui.OppBoard.ApplyResult(c, fireResponse); err != nil {
+		return fmt.Errorf("unknown response: %w", err)
+	}
 	switch fireResponse {
-	case "hit":
-		err := ui.OppBoard.UpdateState(coord, gui.Hit)
-		if err != nil {
-			return fmt.Errorf("failed to update board state: %w", err)
-		}
+	case board.ResultHit:
 		ui.hit++
-	case "miss":
-		err := ui.OppBoard.UpdateState(coord, gui.Miss)
-		if err != nil {
-			return fmt.Errorf("failed to update board state: %w", err)
-		}
+	case board.ResultMiss:
 		ui.miss++
-	case "sunk":
-		c, err := ConvertCoords(coord)
-		if err != nil {
-			return fmt.Errorf("failed to convert coords: %w", err)
-		}
-		err = ui.OppBoard.UpdateState(coord, gui.Hit)
-		if err != nil {
-			return fmt.Errorf("failed to update board state: %w", err)
-		}
-		err = ui.handleSunk(c[0], c[1], nil)
-		if err != nil {
-			return fmt.Errorf("failed to handle sunk: %w", err)
-		}
-	default:
-		return fmt.Errorf("unknown response")
 	}
+	ui.updateHint()
+	ui.updateFleets()
 	return nil
 }
 
-// Recurrently fills the cells with the value "missed" around the sunken ship.
-// Takes as arguments coordinates x and y of the cell with value "hit",
-// and also a table with already visited hit cells.
-func (ui *GameUI) handleSunk(x int, y int, checked *[][2]int) error {
-	board := ui.OppBoard.states
-	if board[x][y] == gui.Miss {
-		return nil
+// Replaces the opponent's board with the given one, e.g. rebuilt from the record of the shots,
+// and sets the counts of hits and misses the accuracy is calculated from.
+func (ui *GameUI) RestoreOppBoard(grid board.Grid, hit int, miss int) {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+	ui.OppBoard.SetGrid(grid)
+	ui.hit = float64(hit)
+	ui.miss = float64(miss)
+	if hit+miss != 0 {
+		ui.calculateAccuracy()
 	}
-	if board[x][y] != gui.Hit {
-		err := ui.OppBoard.UpdateStateWithDigitCoords(x, y, gui.Miss)
-		if err != nil {
-			return fmt.Errorf("failed to update board state: %w", err)
+	ui.updateHint()
+	ui.updateFleets()
+}
+
+// Crosses out the sunk ships in the fleet panels. The lengths of the opponent's sunk ships are taken from their clusters,
+// and the player's ship is sunk when all its tiles are hit. Called with the lock held.
+func (ui *GameUI) updateFleets() {
+	oppGrid := ui.OppBoard.Grid()
+	oppSunk := make([]int, 0)
+	for _, ship := range oppGrid.SunkShips() {
+		oppSunk = append(oppSunk, len(ship))
+	}
+	ui.oppFleet.SetSunk(oppSunk)
+
+	pGrid := ui.PBoard.Grid()
+	pSunk := make([]int, 0)
+	for _, ship := range pGrid.Ships() {
+		if !slices.ContainsFunc(ship, func(c board.Coord) bool { return pGrid.At(c) != board.Hit }) {
+			pSunk = append(pSunk, len(ship))
 		}
-		return nil
 	}
-	if checked == nil {
-		s := make([][2]int, 0)
-		checked = &s
+	ui.pFleet.SetSunk(pSunk)
+}
+
+// Turns on and off highlighting of the cell on the opponent's board that most likely contains a ship.
+func (ui *GameUI) ToggleHint() {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+	ui.hintOn = !ui.hintOn
+	if ui.hintOn {
+		ui.hintBtn.SetText("Hide hint")
+	} else {
+		ui.hintBtn.SetText("Show hint")
 	}
-	*checked = append(*checked, [2]int{x, y})
-	for i := x - 1; i <= x+1; i++ {
-		if i < 0 || i > 9 {
-			continue
-		}
-		for j := y - 1; j <= y+1; j++ {
-			if j > 9 || j < 0 || slices.Contains(*checked, [2]int{i, j}) {
-				continue
-			}
-			err := ui.handleSunk(i, j, checked)
-			if err != nil {
-				return fmt.Errorf("failed to handle sunk: %w", err)
-			}
-		}
+	ui.updateHint()
+}
+
+// Recalculates the recommended target from the current state of the opponent's board and highlights it. Called with the lock held.
+func (ui *GameUI) updateHint() {
+	if !ui.hintOn {
+		ui.OppBoard.ClearHighlight()
+		ui.hintText.SetText("")
+		return
 	}
-	return nil
+	view := ui.OppBoard.Grid()
+	heatmap := ai.Density(view, ai.RemainingFleet(view))
+	best, ok := heatmap.Best()
+	if !ok {
+		ui.OppBoard.ClearHighlight()
+		ui.hintText.SetText("Hint: -")
+		return
+	}
+	ui.OppBoard.SetHighlight(best)
+	ui.hintText.SetText(fmt.Sprintf("Hint: %s (%.0f%%)", best, heatmap.At(best)*100))
 }
 
 func (ui *GameUI) DrawNicks(pNick string, oppNick string) {
@@ -170,33 +271,155 @@ func (ui *GameUI) DrawDescriptions(pDesc string, oppDesc string) {
 	ui.OppBoard.Desc.SetText(oppDesc)
 }
 
-// Listens for clicks on the opponent's board in a loop. Returns the coordinate of the clicked tile if it is empty.
+func (ui *GameUI) SetTurn(text string) {
+	ui.TurnText.SetText(text)
+}
+
+func (ui *GameUI) SetTimer(text string) {
+	ui.Timer.SetBgColor(gui.Black)
+	ui.Timer.SetFgColor(gui.White)
+	ui.Timer.SetText(text)
+}
+
+// Displays the time left in the player's turn. It turns orange as the time runs low, and flashes red just before it runs out.
+func (ui *GameUI) SetCountdown(left time.Duration) {
+	bg, fg := gui.Black, gui.White
+	switch {
+	case left <= countdownCritical && (left/countdownFlash)%2 == 0:
+		bg = gui.Red
+	case left <= countdownCritical:
+		fg = gui.Red
+	case left <= countdownWarning:
+		fg = gui.Orange
+	}
+	ui.Timer.SetBgColor(bg)
+	ui.Timer.SetFgColor(fg)
+	// Rounded up, so the time runs out when 0 is displayed.
+	ui.Timer.SetText(fmt.Sprintf("Time: %d", (left+time.Second-1)/time.Second))
+}
+
+func (ui *GameUI) SetEnd(text string) {
+	ui.EndText.SetText(text)
+}
+
+// Displays the error message, or hides it if the message is empty.
+func (ui *GameUI) ShowError(message string) {
+	ui.ErrorText.SetText(message)
+}
+
+// Displays the health of the connection: green if the last status was received,
+// orange while the requests are retried and red after repeated failures.
+func (ui *GameUI) SetConnection(failures int, lastPoll time.Time) {
+	state, color := "ok", gui.Green
+	switch {
+	case failures >= connectionLostFailures:
+		state, color = "lost", gui.Red
+	case failures > 0:
+		state, color = "retrying", gui.Orange
+	}
+	last := "-"
+	if !lastPoll.IsZero() {
+		last = lastPoll.Format(time.TimeOnly)
+	}
+	ui.connText.SetFgColor(color)
+	ui.connText.SetText(fmt.Sprintf("Connection: %s\nLast poll: %s", state, last))
+}
+
+// Returns a copy of the state of the opponent's board known from the player's shots.
+func (ui *GameUI) OppGrid() board.Grid {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+	return ui.OppBoard.Grid()
+}
+
+// Listens for clicks on the opponent's board and for the keys typed into the key input.
+// Returns the coordinate of the clicked tile, or of the cursor when a firing key is typed, if the tile is empty.
+// Returns an empty coordinate when the context is done.
 func (ui *GameUI) ListenForShot(ctx context.Context) (string, error) {
-	coords := ""
-	// Loop until empty tile is clicked or context is done.
+	// Stops listening for clicks when the shot is chosen with the keys.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	clicks := boardClicks(ctx, ui.OppBoard.Board)
+
+	ticker := time.NewTicker(keyPollInterval)
+	defer ticker.Stop()
+	// Loop until empty tile is chosen or context is done.
 	for {
-		coords = ui.OppBoard.Board.Listen(ctx)
-		// Break if context is done.
-		if coords == "" {
-			break
+		select {
+		case <-ctx.Done():
+			return "", nil
+		case coords := <-clicks:
+			// Check if empty tile is clicked.
+			c, err := board.ParseCoord(coords)
+			if err != nil {
+				return "", fmt.Errorf("failed to convert coords: %w", err)
+			}
+			if ui.moveToUntouched(c) {
+				return coords, nil
+			}
+		case <-ticker.C:
+			if c, ok := ui.readKeys(); ok {
+				return c.String(), nil
+			}
 		}
-		// Check if empty tile is clicked.
-		c, err := ConvertCoords(coords)
-		if err != nil {
-			return "", fmt.Errorf("failed to convert coords: %w", err)
+	}
+}
+
+// Moves the cursor to the tile if it was not shot yet. Reports whether the cursor was moved.
+func (ui *GameUI) moveToUntouched(c board.Coord) bool {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+	if !ui.isUntouched(c) {
+		return false
+	}
+	ui.cursor.MoveTo(c)
+	return true
+}
+
+// Moves the cursor with the keys typed since the last call. Returns the cursor position if a firing key was typed on an empty tile.
+func (ui *GameUI) readKeys() (board.Coord, bool) {
+	keys := ui.keyInput.GetText()
+	if keys == "" {
+		return board.Coord{}, false
+	}
+	ui.keyInput.SetText("")
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+	for _, r := range keys {
+		if ui.cursor.Key(r, ui.isUntouched) {
+			continue
 		}
-		state := ui.OppBoard.states[c[0]][c[1]]
-		if state == "" {
-			break
+		if isConfirmKey(r) && ui.isUntouched(ui.cursor.Pos()) {
+			return ui.cursor.Pos(), true
 		}
 	}
-	return coords, nil
+	ui.OppBoard.SetCursor(ui.cursor.Pos())
+	return board.Coord{}, false
+}
+
+// Reports whether the tile on the opponent's board was not shot yet. Called with the lock held.
+func (ui *GameUI) isUntouched(c board.Coord) bool {
+	return ui.OppBoard.grid.At(c) == board.Empty
 }
 
 func (ui *GameUI) BtnListen(ctx context.Context) string {
-	return ui.abandonArea.Listen(ctx)
+	return ui.btnArea.Listen(ctx)
 }
 
 func (ui *GameUI) CalculateAccuracy() {
-	ui.accuracyText.SetText(fmt.Sprintf("Accuracy: %.2f", (ui.hit/(ui.miss+ui.hit))*100))
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+	ui.calculateAccuracy()
+}
+
+func (ui *GameUI) calculateAccuracy() {
+	ui.accuracyText.SetText(fmt.Sprintf("Accuracy: %s", formatAccuracy(ui.hit, ui.miss)))
+}
+
+// Returns the percentage of hits among the shots, or "-" if no shots were counted.
+func formatAccuracy(hit float64, miss float64) string {
+	if hit+miss == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f", (hit/(miss+hit))*100)
 }
@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"battleship_client/board"
+	"battleship_client/shotlog"
+	"slices"
+	"sync"
+	"testing"
+
+	gui "github.com/RostKoff/warships-gui/v2"
+)
+
+// Creates the game screen with only the elements changed by the game, so it can be used without a terminal.
+func newTestGameUI() *GameUI {
+	return &GameUI{
+		PBoard:       InitGameBoard(1, 5, nil),
+		OppBoard:     InitGameBoard(50, 5, nil),
+		accuracyText: gui.NewText(20, 1, "", nil),
+		hintText:     gui.NewText(20, 3, "", nil),
+		hintBtn:      gui.NewButton(80, 1, "Show hint", gui.NewButtonConfig()),
+		oppFleet:     NewFleetPanel(96, 5, "Enemy fleet"),
+		pFleet:       NewFleetPanel(96, 18, "Your fleet"),
+	}
+}
+
+// Runs the calls made by the goroutines of a game at the same time, so `go test -race` reports unguarded state.
+func TestGameUIConcurrentAccess(t *testing.T) {
+	ui := newTestGameUI()
+	coords := board.AllCoords()
+	// Released once all the goroutines are started, so their calls overlap.
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	run := func(f func(c board.Coord)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			for round := 0; round < 3; round++ {
+				for _, c := range coords {
+					f(c)
+				}
+			}
+		}()
+	}
+	// Game events.
+	run(func(c board.Coord) {
+		if err := ui.HandlePShot(board.ResultMiss, c.String()); err != nil {
+			t.Errorf("HandlePShot(%s) error: %s", c, err)
+		}
+		ui.CalculateAccuracy()
+	})
+	run(func(c board.Coord) {
+		if err := ui.HandleOppShots([]string{"A1"}, []string{c.String()}); err != nil {
+			t.Errorf("HandleOppShots(%s) error: %s", c, err)
+		}
+	})
+	// Shot chosen by the player.
+	run(func(c board.Coord) { ui.moveToUntouched(c) })
+	// Hint button.
+	run(func(board.Coord) { ui.ToggleHint() })
+	// Autoplay and the random shot before the time runs out.
+	run(func(board.Coord) {
+		grid := ui.OppGrid()
+		grid.Find(board.Empty)
+	})
+	close(start)
+	wg.Wait()
+
+	grid := ui.OppGrid()
+	if empty := grid.Find(board.Empty); len(empty) != 0 {
+		t.Errorf("cells left empty after all were shot: %v", empty)
+	}
+}
+
+// Repaints the opponent's board from the record of the shots, e.g. after the game is resumed.
+func TestRestoreOppBoard(t *testing.T) {
+	shots := shotlog.New()
+	for _, shot := range []shotlog.Shot{{Coord: "A1", Result: board.ResultHit}, {Coord: "A2", Result: board.ResultSunk}, {Coord: "E5", Result: board.ResultMiss}} {
+		if err := shots.Add(shot.Coord, shot.Result); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ui := newTestGameUI()
+	hit, miss, _ := shots.Counts()
+	ui.RestoreOppBoard(shots.Grid(), hit, miss)
+
+	grid := ui.OppGrid()
+	if grid != shots.Grid() {
+		t.Errorf("opponent's board differs from the record of the shots")
+	}
+	// The cells around the sunk ship are repainted as missed.
+	for _, c := range []string{"B1", "B2", "B3", "A3"} {
+		coord, _ := board.ParseCoord(c)
+		if grid.At(coord) != board.Miss {
+			t.Errorf("cell %s next to the sunk ship = %v, want %v", c, grid.At(coord), board.Miss)
+		}
+	}
+	if ui.hit != 1 || ui.miss != 1 {
+		t.Errorf("counts of the shots = %v hits and %v misses, want 1 and 1", ui.hit, ui.miss)
+	}
+}
+
+// Ships of each length, the shots sinking them and the ships crossed out in the fleet panel afterwards.
+var sinkingTests = []struct {
+	name     string
+	ship     []string
+	wantSunk []int
+}{
+	{name: "four-tile ship", ship: []string{"A1", "B1", "C1", "D1"}, wantSunk: []int{4}},
+	{name: "three-tile ship", ship: []string{"A3", "A4", "A5"}, wantSunk: []int{4, 3}},
+	{name: "two-tile ship", ship: []string{"J1", "J2"}, wantSunk: []int{4, 3, 2}},
+	{name: "single-tile ship", ship: []string{"E8"}, wantSunk: []int{4, 3, 2, 1}},
+}
+
+func TestFleetPanelOpponentShips(t *testing.T) {
+	ui := newTestGameUI()
+	// A ship that is only hit is not crossed out.
+	if err := ui.HandlePShot(board.ResultHit, "J10"); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range sinkingTests {
+		for i, coord := range tt.ship {
+			result := board.ResultHit
+			if i == len(tt.ship)-1 {
+				result = board.ResultSunk
+			}
+			if err := ui.HandlePShot(result, coord); err != nil {
+				t.Fatalf("HandlePShot(%s, %s) error: %s", result, coord, err)
+			}
+			if i < len(tt.ship)-1 && len(ui.oppFleet.Sunk()) != len(tt.wantSunk)-1 {
+				t.Fatalf("%s crossed out before it was sunk", tt.name)
+			}
+		}
+		if got := ui.oppFleet.Sunk(); !slices.Equal(got, tt.wantSunk) {
+			t.Errorf("after sinking the %s crossed out ships = %v, want %v", tt.name, got, tt.wantSunk)
+		}
+	}
+}
+
+func TestFleetPanelPlayerShips(t *testing.T) {
+	ui := newTestGameUI()
+	ships := []string{"J9", "J10"}
+	for _, tt := range sinkingTests {
+		ships = append(ships, tt.ship...)
+	}
+	if err := ui.DrawShips(ships); err != nil {
+		t.Fatal(err)
+	}
+	// The opponent's shots are sent all at once with every status, and a ship that is only hit is not crossed out.
+	oppShots := []string{"J9", "E5"}
+	for _, tt := range sinkingTests {
+		if err := ui.HandleOppShots(ships, append(slices.Clone(oppShots), tt.ship[1:]...)); err != nil {
+			t.Fatal(err)
+		}
+		if len(tt.ship) > 1 && len(ui.pFleet.Sunk()) != len(tt.wantSunk)-1 {
+			t.Fatalf("%s crossed out before it was sunk", tt.name)
+		}
+		oppShots = append(oppShots, tt.ship...)
+		if err := ui.HandleOppShots(ships, oppShots); err != nil {
+			t.Fatal(err)
+		}
+		if got := ui.pFleet.Sunk(); !slices.Equal(got, tt.wantSunk) {
+			t.Errorf("after the %s was sunk crossed out ships = %v, want %v", tt.name, got, tt.wantSunk)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package cli
+
+import (
+	"battleship_client/history"
+	"context"
+	"fmt"
+
+	wGui "github.com/RostKoff/warships-gui/v2"
+)
+
+const (
+	OlderOpt   = "older"
+	NewerOpt   = "newer"
+	historyTop = 6
+	// Number of games displayed on a single page of the list.
+	historyPageSize = 8
+)
+
+// Widths of the columns of the game list.
+var historyColumns = []int{18, 18, 14, 10, 12}
+
+type HistoryUI struct {
+	Controller *wGui.GUI
+	btnArea    *wGui.HandleArea
+	btnMapping map[string]wGui.Physical
+	infoTxt    *wGui.Text
+	pageTxt    *wGui.Text
+	rows       []Row
+	games      []history.Game
+	page       int
+}
+
+// Creates and draws the header of the game list and its buttons. The games are drawn with `DrawGames`.
+func InitHistory(controller *wGui.GUI) *HistoryUI {
+	width := 0
+	for _, w := range historyColumns {
+		width += w
+	}
+	titleCfg := wGui.NewButtonConfig()
+	titleCfg.Width = width
+	title := wGui.NewButton(2, 1, "Game history", titleCfg)
+
+	btnCfg := wGui.NewButtonConfig()
+	btnCfg.Width = 20
+	btnCfg.BgColor = wGui.Grey
+	backBtn := wGui.NewButton(2+width+2, 1, "Go back", btnCfg)
+	btnCfg.BgColor = wGui.Blue
+	newerBtn := wGui.NewButton(2+width+2, 5, "Newer games", btnCfg)
+	olderBtn := wGui.NewButton(2+width+2, 9, "Older games", btnCfg)
+	btnMapping := map[string]wGui.Physical{
+		BackOpt:  backBtn,
+		NewerOpt: newerBtn,
+		OlderOpt: olderBtn,
+	}
+	btnArea := wGui.NewHandleArea(btnMapping)
+
+	infoTxt := wGui.NewText(2, 4, "Loading...", nil)
+	pageTxt := wGui.NewText(2+width+2, 13, "", nil)
+
+	drawables := []wGui.Drawable{title, backBtn, newerBtn, olderBtn, btnArea, infoTxt, pageTxt}
+	header := historyRow(historyTop, "Date", "Opponent", "Outcome", "Shots", "Accuracy")
+	for _, btn := range header.GetButtons() {
+		drawables = append(drawables, btn)
+	}
+	for _, drawable := range drawables {
+		controller.Draw(drawable)
+	}
+	return &HistoryUI{
+		Controller: controller,
+		btnArea:    btnArea,
+		btnMapping: btnMapping,
+		infoTxt:    infoTxt,
+		pageTxt:    pageTxt,
+	}
+}
+
+// Displays the first page of the given games. Clicking the row of a game makes `Listen` return its ID.
+func (ui *HistoryUI) DrawGames(games []history.Game) {
+	ui.games = games
+	ui.page = 0
+	if len(games) == 0 {
+		ui.infoTxt.SetText("No games were recorded yet")
+	} else {
+		ui.infoTxt.SetText("Click a game to replay it")
+	}
+	ui.drawPage()
+}
+
+// Displays the page with older games, if there is one.
+func (ui *HistoryUI) OlderPage() {
+	if (ui.page+1)*historyPageSize < len(ui.games) {
+		ui.page++
+		ui.drawPage()
+	}
+}
+
+// Displays the page with newer games, if there is one.
+func (ui *HistoryUI) NewerPage() {
+	if ui.page > 0 {
+		ui.page--
+		ui.drawPage()
+	}
+}
+
+// Displays the message above the list, e.g. when the games could not be loaded.
+func (ui *HistoryUI) ShowInfo(message string) {
+	ui.infoTxt.SetText(message)
+}
+
+// Listens for clicks on the buttons and the rows, and returns the key of the clicked button or the ID of the clicked game.
+func (ui *HistoryUI) Listen(ctx context.Context) string {
+	return ui.btnArea.Listen(ctx)
+}
+
+func (ui *HistoryUI) drawPage() {
+	ui.clearRows()
+	areaMap := make(map[string]wGui.Physical, len(ui.btnMapping)+historyPageSize)
+	for key, btn := range ui.btnMapping {
+		areaMap[key] = btn
+	}
+	start := ui.page * historyPageSize
+	end := min(start+historyPageSize, len(ui.games))
+	for i, game := range ui.games[start:end] {
+		hit, miss := shotCounts(game.Shots())
+		row := historyRow(historyTop+(i+1)*3,
+			game.StartedAt.Local().Format("2006-01-02 15:04"),
+			game.Opponent,
+			outcomeLabel(game.Outcome),
+			fmt.Sprintf("%d", len(game.Shots())),
+			formatAccuracy(hit, miss),
+		)
+		for _, btn := range row.GetButtons() {
+			ui.Controller.Draw(btn)
+		}
+		ui.rows = append(ui.rows, row)
+		areaMap[game.ID] = row
+	}
+	ui.btnArea.SetClickablesOn(areaMap)
+	ui.Controller.Draw(ui.btnArea)
+	if pages := (len(ui.games) + historyPageSize - 1) / historyPageSize; pages > 1 {
+		ui.pageTxt.SetText(fmt.Sprintf("Page %d/%d", ui.page+1, pages))
+	} else {
+		ui.pageTxt.SetText("")
+	}
+}
+
+// Removes all the rows with games from the screen.
+func (ui *HistoryUI) clearRows() {
+	ui.Controller.Remove(ui.btnArea)
+	for _, row := range ui.rows {
+		for _, btn := range row.GetButtons() {
+			ui.Controller.Remove(btn)
+		}
+	}
+	ui.rows = nil
+}
+
+// Creates a row of buttons, one for each column of the game list.
+func historyRow(y int, columns ...string) Row {
+	cfg := wGui.NewButtonConfig()
+	btns := make([]*wGui.Button, len(columns))
+	x := 2
+	for i, text := range columns {
+		cfg.Width = historyColumns[i]
+		btns[i] = wGui.NewButton(x, y, text, cfg)
+		x += historyColumns[i]
+	}
+	return NewRow(btns)
+}
+
+// Returns the text displayed for the outcome of a recorded game.
+func outcomeLabel(outcome string) string {
+	switch outcome {
+	case history.OutcomeWin:
+		return "Won"
+	case history.OutcomeLose:
+		return "Lost"
+	case history.OutcomeAbandoned:
+		return "Abandoned"
+	case history.OutcomeSessionLost:
+		return "Session lost"
+	}
+	return "Unfinished"
+}
+
+// Counts the hits and misses among the player's shots the same way the game screen does for its accuracy.
+func shotCounts(shots []history.Event) (hit float64, miss float64) {
+	for _, e := range shots {
+		switch e.Result {
+		case "hit":
+			hit++
+		case "miss":
+			miss++
+		}
+	}
+	return hit, miss
+}
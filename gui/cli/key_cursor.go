@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"battleship_client/board"
+	"context"
+	"time"
+	"unicode"
+
+	wGui "github.com/RostKoff/warships-gui/v2"
+)
+
+// Interval in which the keys typed into the key input are read.
+const keyPollInterval = 50 * time.Millisecond
+
+// Help displayed next to the key input.
+const keyHelp = "hjkl: move, B7: go to cell"
+
+// The GUI library reports only the clicks and the text typed into the text fields, not the key presses, so the arrow keys
+// and Enter cannot be handled. The keys are typed into the key input instead, which is read every `keyPollInterval`.
+const keyInputNote = "No arrow keys or Enter: the GUI\nonly reads the keys typed below"
+
+// Directions of the movement keys.
+var moveKeys = map[rune][2]int{
+	'h': {-1, 0},
+	'l': {1, 0},
+	'k': {0, -1},
+	'j': {0, 1},
+}
+
+// Cursor moved over a board with the keys typed by the player.
+// Lowercase h, j, k and l move the cursor by one cell, and a coordinate typed with an uppercase letter, e.g. "B7", moves it to that cell.
+type KeyCursor struct {
+	pos board.Coord
+	// Coordinate being typed.
+	typed string
+}
+
+// Returns the cell the cursor is on.
+func (k *KeyCursor) Pos() board.Coord {
+	return k.pos
+}
+
+// Moves the cursor to the cell, regardless of which cells are allowed.
+func (k *KeyCursor) MoveTo(c board.Coord) {
+	if c.Valid() {
+		k.pos = c
+	}
+}
+
+// Handles the key and reports whether it moved the cursor or was a part of a typed coordinate.
+// The cursor only stops on the cells for which `allowed` returns true, the movement keys skip the other cells.
+// Keys that are not handled, e.g. the ones that fire, are left to the caller.
+func (k *KeyCursor) Key(r rune, allowed func(board.Coord) bool) bool {
+	if dir, ok := moveKeys[r]; ok {
+		k.typed = ""
+		for c := k.pos.Add(dir[0], dir[1]); c.Valid(); c = c.Add(dir[0], dir[1]) {
+			if allowed(c) {
+				k.pos = c
+				break
+			}
+		}
+		return true
+	}
+	switch {
+	case r >= 'A' && r < 'A'+board.Size:
+		k.typed = string(r)
+	case unicode.IsDigit(r) && k.typed != "" && len(k.typed) < 3:
+		k.typed += string(r)
+	default:
+		k.typed = ""
+		return false
+	}
+	if c, err := board.ParseCoord(k.typed); err == nil && allowed(c) {
+		k.pos = c
+	}
+	return true
+}
+
+// Reports whether the key confirms the action at the cursor, e.g. firing or placing a ship.
+// Only the space can be typed into the key input, the line breaks are accepted in case the GUI passes them on.
+func isConfirmKey(r rune) bool {
+	return r == ' ' || r == '\n' || r == '\r'
+}
+
+// Listens for clicks on the board until the context is done and sends the clicked coordinates to the returned channel.
+// Makes it possible to wait for the clicks and the keys at the same time.
+func boardClicks(ctx context.Context, b *wGui.Board) <-chan string {
+	clicks := make(chan string)
+	go func() {
+		for {
+			coords := b.Listen(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case clicks <- coords:
+			}
+		}
+	}()
+	return clicks
+}
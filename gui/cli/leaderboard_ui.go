@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"battleship_client/api/client"
+	"context"
+	"fmt"
+
+	wGui "github.com/RostKoff/warships-gui/v2"
+)
+
+const (
+	BackOpt           = "back"
+	RefreshStatsOpt   = "refreshStats"
+	leaderboardColumn = 12
+	leaderboardTop    = 6
+)
+
+type LeaderboardUI struct {
+	Controller *wGui.GUI
+	btnArea    *wGui.HandleArea
+	infoTxt    *wGui.Text
+	rows       []Row
+}
+
+// Creates and draws the header of the leaderboard and its buttons. The rows are drawn with `DrawStats`.
+func InitLeaderboard(controller *wGui.GUI) *LeaderboardUI {
+	titleCfg := wGui.NewButtonConfig()
+	titleCfg.Width = leaderboardColumn * 5
+	title := wGui.NewButton(2, 1, "Leaderboard", titleCfg)
+
+	btnCfg := wGui.NewButtonConfig()
+	btnCfg.Width = 20
+	btnCfg.BgColor = wGui.Grey
+	backBtn := wGui.NewButton(2+titleCfg.Width+2, 1, "Go back", btnCfg)
+	btnCfg.BgColor = wGui.Blue
+	refreshBtn := wGui.NewButton(2+titleCfg.Width+2, 5, "Refresh", btnCfg)
+	btnArea := wGui.NewHandleArea(map[string]wGui.Physical{
+		BackOpt:         backBtn,
+		RefreshStatsOpt: refreshBtn,
+	})
+
+	infoTxt := wGui.NewText(2, 4, "Loading...", nil)
+
+	drawables := []wGui.Drawable{title, backBtn, refreshBtn, btnArea, infoTxt}
+	header := statsRow(leaderboardTop, "Rank", "Nick", "Games", "Wins", "Points")
+	for _, btn := range header.GetButtons() {
+		drawables = append(drawables, btn)
+	}
+	for _, drawable := range drawables {
+		controller.Draw(drawable)
+	}
+	return &LeaderboardUI{
+		Controller: controller,
+		btnArea:    btnArea,
+		infoTxt:    infoTxt,
+	}
+}
+
+// Displays a row for each of the given players. The row of the player with the given nick is highlighted.
+// If that player is not among the given ones, but `own` is not nil, their row is displayed below the others.
+func (ui *LeaderboardUI) DrawStats(stats []client.PlayerStatsResponse, nick string, own *client.PlayerStatsResponse) {
+	ui.clearRows()
+	y := leaderboardTop
+	found := false
+	for _, st := range stats {
+		y += 3
+		row := ui.drawStatsRow(y, st)
+		if st.Nick == nick {
+			found = true
+			row.SetBgColor(wGui.White)
+			row.SetFgColor(wGui.Black)
+		}
+	}
+	if !found && own != nil {
+		y += 3
+		row := ui.drawStatsRow(y, *own)
+		row.SetBgColor(wGui.White)
+		row.SetFgColor(wGui.Black)
+	}
+	if len(stats) == 0 {
+		ui.infoTxt.SetText("No games were played yet")
+	} else {
+		ui.infoTxt.SetText("")
+	}
+}
+
+// Displays the message above the table, e.g. when the statistics could not be fetched.
+func (ui *LeaderboardUI) ShowInfo(message string) {
+	ui.infoTxt.SetText(message)
+}
+
+// Listens for clicks on the leaderboard buttons and returns the key of the clicked one.
+func (ui *LeaderboardUI) Listen(ctx context.Context) string {
+	return ui.btnArea.Listen(ctx)
+}
+
+func (ui *LeaderboardUI) drawStatsRow(y int, st client.PlayerStatsResponse) Row {
+	row := statsRow(y, fmt.Sprintf("%d", st.Rank), st.Nick, fmt.Sprintf("%d", st.Games), fmt.Sprintf("%d", st.Wins), fmt.Sprintf("%d", st.Points))
+	for _, btn := range row.GetButtons() {
+		ui.Controller.Draw(btn)
+	}
+	ui.rows = append(ui.rows, row)
+	return row
+}
+
+// Removes all the rows with statistics from the screen.
+func (ui *LeaderboardUI) clearRows() {
+	for _, row := range ui.rows {
+		for _, btn := range row.GetButtons() {
+			ui.Controller.Remove(btn)
+		}
+	}
+	ui.rows = nil
+}
+
+// Creates a row of buttons, one for each column of the leaderboard.
+func statsRow(y int, columns ...string) Row {
+	cfg := wGui.NewButtonConfig()
+	cfg.Width = leaderboardColumn
+	btns := make([]*wGui.Button, len(columns))
+	for i, text := range columns {
+		btns[i] = wGui.NewButton(2+i*leaderboardColumn, y, text, cfg)
+	}
+	return NewRow(btns)
+}
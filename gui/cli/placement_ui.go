@@ -1,10 +1,15 @@
 package cli
 
 import (
+	"battleship_client/board"
 	"context"
 	"fmt"
+	"math/rand"
 	"slices"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	wGui "github.com/RostKoff/warships-gui/v2"
 )
@@ -17,24 +22,61 @@ const (
 	delOpt       = "delete"
 	PlacementOpt = "placement"
 	GoBack       = "goBack"
+	RandomizeOpt = "randomize"
+	// Saves the placed fleet under the name typed by the player.
+	SaveLayoutOpt = "saveLayout"
+	// Shows or hides the list of saved layouts.
+	LoadLayoutOpt = "loadLayout"
+	// Write the placed fleet to the file typed in place of the layout name, and place the fleet read from it, so layouts can be shared.
+	ExportLayoutOpt = "exportLayout"
+	ImportLayoutOpt = "importLayout"
+	// Prefix of the keys returned by `SetBtnListen` for the rows of the layout list.
+	layoutKeyPrefix = "layout:"
+	// Number of layouts displayed in the list and its position, below the ship selection.
+	layoutListSize = 10
+	layoutListX    = 50
+	layoutListY    = 19
+	// The GUI library reports only the clicks on the board, not the position of the mouse, so the ghost is moved with the keys.
+	ghostNote = "The ghost does not follow the mouse"
 )
 
 type shipPlacement struct {
-	cluster      [][2]int
-	surroundings [][2]int
+	cluster      board.Ship
+	surroundings []board.Coord
 }
 
 type PlacementUI struct {
-	controller   *wGui.GUI
+	controller *wGui.GUI
+	// Guards the tiles, the placed ships, their counters, the selected ship and the ghost. The ships are placed with the clicks
+	// on the board while the buttons, e.g. "Randomize", replace the whole fleet, each on its own goroutine.
+	mu           sync.Mutex
 	board        *wGui.Board
 	shipsArea    *wGui.HandleArea
 	shipsTxt     *wGui.Text
 	setShipsBtn  *wGui.Button
 	btnsArea     *wGui.HandleArea
+	errorTxt     *wGui.Text
 	tiles        [10][10]wGui.State
 	ships        map[string]Row
 	selectedShip string
 	shipCoords   []string
+	// Keys typed into the input move the ghost of the selected ship, rotate it and place it.
+	keyInput    *wGui.TextField
+	keyHelpText *wGui.Text
+	cursor      KeyCursor
+	// Cells of the ship that would be placed at the cursor, nil when no ship is being placed with the keys.
+	ghost         []board.Coord
+	ghostVertical bool
+	// Buttons of `btnsArea` other than the rows of the layout list.
+	btnMapping  map[string]wGui.Physical
+	layoutInput *wGui.TextField
+	layoutsTxt  *wGui.Text
+	layoutRows  []Row
+	layoutsOn   bool
+	// Incremented whenever the whole fleet is replaced, so the ship placed or deleted before does not change the new fleet.
+	fleetVersion int
+	// Cancels the ship being placed or deleted. Calling it after the ship was placed does nothing.
+	cancelShip context.CancelFunc
 }
 
 func InitPlacement(controller *wGui.GUI) *PlacementUI {
@@ -90,7 +132,37 @@ func InitPlacement(controller *wGui.GUI) *PlacementUI {
 	setShipsBtn := wGui.NewButton(1, 24, "Random configuration", setShipsCfg)
 	w, _ := setShipsBtn.Size()
 	goBackBtn := wGui.NewButton(2+w, 24, "Go back", setShipsCfg)
-	btnsArea := wGui.NewHandleArea(map[string]wGui.Physical{PlacementOpt: setShipsBtn, GoBack: goBackBtn})
+	gw, _ := goBackBtn.Size()
+	randomCfg := wGui.NewButtonConfig()
+	randomCfg.BgColor = wGui.Blue
+	randomBtn := wGui.NewButton(3+w+gw, 24, "Randomize", randomCfg)
+	layoutTxt := wGui.NewText(1, 28, "Layout name or file", nil)
+	layoutCfg := wGui.NewTextFieldConfig()
+	layoutCfg.UnfilledChar = '_'
+	layoutCfg.InputOn = true
+	layoutInput := wGui.NewTextField(1, 29, 20, 1, layoutCfg)
+	saveLayoutBtn := wGui.NewButton(23, 28, "Save layout", randomCfg)
+	sw, _ := saveLayoutBtn.Size()
+	loadLayoutBtn := wGui.NewButton(24+sw, 28, "Load layout", randomCfg)
+	lw, _ := loadLayoutBtn.Size()
+	exportLayoutBtn := wGui.NewButton(25+sw+lw, 28, "Export", randomCfg)
+	ew, _ := exportLayoutBtn.Size()
+	importLayoutBtn := wGui.NewButton(26+sw+lw+ew, 28, "Import", randomCfg)
+	btnMapping := map[string]wGui.Physical{
+		PlacementOpt:    setShipsBtn,
+		GoBack:          goBackBtn,
+		RandomizeOpt:    randomBtn,
+		SaveLayoutOpt:   saveLayoutBtn,
+		LoadLayoutOpt:   loadLayoutBtn,
+		ExportLayoutOpt: exportLayoutBtn,
+		ImportLayoutOpt: importLayoutBtn,
+	}
+	btnsArea := wGui.NewHandleArea(btnMapping)
+	errorTxt := wGui.NewText(1, 26, "", nil)
+	errorTxt.SetFgColor(wGui.Red)
+	keyCfg := wGui.NewTextFieldConfig()
+	keyCfg.UnfilledChar = '_'
+	keyCfg.InputOn = true
 
 	ui := &PlacementUI{
 		controller:  controller,
@@ -101,9 +173,16 @@ func InitPlacement(controller *wGui.GUI) *PlacementUI {
 		tiles:       states,
 		setShipsBtn: setShipsBtn,
 		btnsArea:    btnsArea,
+		errorTxt:    errorTxt,
+		keyInput:    wGui.NewTextField(x, y+6, 20, 1, keyCfg),
+		keyHelpText: wGui.NewText(x, y+2, keyHelp+", r: rotate, space: place\n"+keyInputNote+"\n"+ghostNote, nil),
+		btnMapping:  btnMapping,
+		layoutInput: layoutInput,
+		layoutsTxt:  wGui.NewText(layoutListX, layoutListY, "", nil),
 	}
 
-	drawables = append(drawables, ui.board, ui.shipsArea, shipsTxt, ui.btnsArea, ui.setShipsBtn, goBackBtn)
+	drawables = append(drawables, ui.board, ui.shipsArea, shipsTxt, ui.btnsArea, ui.setShipsBtn, goBackBtn, randomBtn, errorTxt, ui.keyInput, ui.keyHelpText,
+		layoutTxt, layoutInput, saveLayoutBtn, loadLayoutBtn, exportLayoutBtn, importLayoutBtn, ui.layoutsTxt)
 	for _, drawable := range drawables {
 		ui.controller.Draw(drawable)
 	}
@@ -111,6 +190,12 @@ func InitPlacement(controller *wGui.GUI) *PlacementUI {
 }
 
 func (ui *PlacementUI) ShipsSelect(sKey string) (string, error) {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+	return ui.shipsSelect(sKey)
+}
+
+func (ui *PlacementUI) shipsSelect(sKey string) (string, error) {
 	row, ok := ui.ships[sKey]
 	if !ok {
 		return "", fmt.Errorf("ship not found")
@@ -155,14 +240,20 @@ func (ui *PlacementUI) ShipsSelect(sKey string) (string, error) {
 	return ui.selectedShip, nil
 }
 
-func (ui *PlacementUI) BoardClick(lCoord, nCoord int, isFirst bool) bool {
-	state := ui.tiles[lCoord][nCoord]
+func (ui *PlacementUI) BoardClick(c board.Coord, isFirst bool) bool {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+	return ui.boardClick(c, isFirst)
+}
+
+func (ui *PlacementUI) boardClick(c board.Coord, isFirst bool) bool {
+	state := ui.tiles[c.X][c.Y]
 	if (state != wGui.Empty && isFirst) || (state != wGui.Emphasis && !isFirst) {
 		return false
 	}
-	ui.tiles[lCoord][nCoord] = wGui.Ship
-	ui.changeEmphasisAround(lCoord, nCoord, false)
-	ui.board.SetStates(ui.tiles)
+	ui.tiles[c.X][c.Y] = wGui.Ship
+	ui.changeEmphasisAround(c, false)
+	ui.render()
 	return true
 }
 
@@ -189,39 +280,90 @@ func (ui *PlacementUI) Listen(ctx context.Context) error {
 	}
 	innerCtx, canc := context.WithCancel(ctx)
 	go func(ctx context.Context) {
-		sKey = ui.shipsArea.Listen(ctx)
+		next := ui.shipsArea.Listen(ctx)
 		select {
 		case <-ctx.Done():
 			return
 		default:
-			ui.ShipsSelect(sKey)
+			ui.ShipsSelect(next)
 			canc()
 		}
 
 	}(endCtx)
 	if tilesNum == -1 {
-		shipNum, err := ui.deleteShip(innerCtx)
-		if err != nil {
+		if _, err := ui.deleteShip(innerCtx); err != nil {
 			return err
 		}
-		ui.changeShipCounter(fmt.Sprintf("%dship", shipNum), false)
 	} else {
-		succ := ui.placeShip(tilesNum, innerCtx)
-		if succ {
-			ui.changeShipCounter(sKey, true)
-		}
+		ui.placeShip(tilesNum, innerCtx)
 	}
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
 	if sKey == ui.selectedShip {
-		ui.ShipsSelect(sKey)
+		ui.shipsSelect(sKey)
 	}
-	if len(ui.shipCoords) == 20 {
+	ui.updateSetBtn()
+	return nil
+}
+
+// Changes the button that submits the board depending on whether the whole fleet is placed.
+func (ui *PlacementUI) updateSetBtn() {
+	if len(ui.shipCoords) == board.FleetCells {
 		ui.setShipsBtn.SetBgColor(wGui.Green)
 		ui.setShipsBtn.SetText("Set configuration")
 	} else {
 		ui.setShipsBtn.SetBgColor(wGui.Red)
 		ui.setShipsBtn.SetText("Random configuration")
 	}
-	return nil
+}
+
+// Replaces all the placed ships with the given ones and updates the ship counters accordingly.
+// The ship being placed or deleted is cancelled, so it is neither added to the new fleet nor restores the old one.
+func (ui *PlacementUI) SetShips(ships []board.Ship) {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+	if ui.cancelShip != nil {
+		ui.cancelShip()
+	}
+	ui.fleetVersion++
+	ui.ghost = nil
+	if ui.selectedShip != "" {
+		ui.shipsSelect(ui.selectedShip)
+	}
+	tiles := [10][10]wGui.State{}
+	for _, c := range board.AllCoords() {
+		tiles[c.X][c.Y] = wGui.Empty
+	}
+	placed := make(map[int]int)
+	shipCoords := make([]string, 0, board.FleetCells)
+	for _, ship := range ships {
+		for _, c := range ship.Surroundings() {
+			tiles[c.X][c.Y] = wGui.Blocked
+		}
+		for _, c := range ship {
+			tiles[c.X][c.Y] = wGui.Ship
+		}
+		shipCoords = append(shipCoords, board.FormatCoords(ship)...)
+		placed[len(ship)]++
+	}
+	// Every row starts with the count of ships of its length that are left to place.
+	for i := 1; i <= 4; i++ {
+		row, ok := ui.ships[fmt.Sprintf("%dship", i)]
+		if !ok {
+			continue
+		}
+		row.GetButtons()[0].SetText(fmt.Sprintf("%d", 5-i-placed[i]))
+	}
+	ui.tiles = tiles
+	ui.shipCoords = shipCoords
+	ui.render()
+	ui.updateSetBtn()
+	ui.ShowError("")
+}
+
+// Fills the board with a randomly generated fleet, replacing the ships placed so far.
+func (ui *PlacementUI) Randomize(rng *rand.Rand) {
+	ui.SetShips(board.RandomFleet(rng))
 }
 
 func (ui *PlacementUI) changeShipCounter(sKey string, decrease bool) {
@@ -248,48 +390,173 @@ func (ui *PlacementUI) changeShipCounter(sKey string, decrease bool) {
 	countBtn.SetText(fmt.Sprintf("%d", newCount))
 }
 
+// Places the ship of the given length either tile by tile with clicks, or at once with the keys.
+// Returns false if the placement was cancelled, also by replacing the whole fleet.
 func (ui *PlacementUI) placeShip(tilesNum int, ctx context.Context) bool {
 	if tilesNum == 0 {
 		return false
 	}
-	shipCoords := make([]string, tilesNum)
-	var coords [2]int
-	var err error
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	clicks := boardClicks(ctx, ui.board)
+	ticker := time.NewTicker(keyPollInterval)
+	defer ticker.Stop()
+
+	shipCoords := make([]string, 0, tilesNum)
+	var coords board.Coord
+	ui.mu.Lock()
+	ui.cancelShip = cancel
+	version := ui.fleetVersion
 	bCopy := ui.tiles
-	for i := 0; i < tilesNum; {
+	// Keys typed before the ship was selected are ignored.
+	ui.keyInput.SetText("")
+	ui.moveGhost(tilesNum)
+	ui.mu.Unlock()
+	for len(shipCoords) < tilesNum {
 		select {
 		case <-ctx.Done():
-			ui.board.SetStates(bCopy)
+			ui.mu.Lock()
+			defer ui.mu.Unlock()
+			// Discard the tiles of the unfinished ship, so they do not stay on the board without being in `shipCoords`,
+			// unless the whole fleet was replaced in the meantime.
+			if ui.fleetVersion == version {
+				ui.tiles = bCopy
+			}
+			ui.ghost = nil
+			ui.render()
 			return false
-		default:
-			tile := ui.board.Listen(ctx)
+		case tile := <-clicks:
 			ui.controller.Log(tile)
-			shipCoords[i] = tile
-			coords, err = ConvertCoords(tile)
+			c, err := board.ParseCoord(tile)
 			if err != nil {
 				continue
 			}
-			isFirst := false
-			if i == 0 {
-				isFirst = true
+			ui.mu.Lock()
+			// After the fleet was replaced the context is cancelled, and the next iteration returns.
+			if ui.fleetVersion == version && ui.boardClick(c, len(shipCoords) == 0) {
+				coords = c
+				shipCoords = append(shipCoords, tile)
+				// The ship is finished with clicks, so the keys no longer place it.
+				ui.ghost = nil
+				ui.render()
 			}
-
-			placed := ui.BoardClick(coords[0], coords[1], isFirst)
-			if placed {
-				i++
+			ui.mu.Unlock()
+		case <-ticker.C:
+			// The keys can only place the whole ship, not finish the one started with clicks.
+			if len(shipCoords) != 0 {
+				continue
 			}
+			ui.mu.Lock()
+			if ui.fleetVersion == version {
+				if ship, ok := ui.readKeys(tilesNum); ok {
+					for _, c := range ship {
+						ui.tiles[c.X][c.Y] = wGui.Ship
+					}
+					coords = ship[0]
+					shipCoords = board.FormatCoords(ship)
+				}
+			}
+			ui.mu.Unlock()
 		}
 	}
-	_, surrodings := getPlacement(coords[0], coords[1], ui.tiles)
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+	if ui.fleetVersion != version {
+		return false
+	}
+	ui.ghost = nil
+	grid := ui.grid()
+	_, surrodings := grid.Cluster(coords)
 	for _, sCoords := range surrodings {
-		ui.tiles[sCoords[0]][sCoords[1]] = wGui.Blocked
+		ui.tiles[sCoords.X][sCoords.Y] = wGui.Blocked
 	}
-	ui.board.SetStates(ui.tiles)
+	ui.render()
 	ui.shipCoords = append(ui.shipCoords, shipCoords...)
+	ui.changeShipCounter(fmt.Sprintf("%dship", tilesNum), true)
 	return true
 }
 
+// Moves the ghost with the keys typed since the last call.
+// Returns the cells of the ghost if a placing key was typed and the ship can be placed there.
+func (ui *PlacementUI) readKeys(tilesNum int) (board.Ship, bool) {
+	keys := ui.keyInput.GetText()
+	if keys == "" {
+		return nil, false
+	}
+	ui.keyInput.SetText("")
+	anywhere := func(board.Coord) bool { return true }
+	for _, r := range keys {
+		if ui.cursor.Key(r, anywhere) {
+			continue
+		}
+		switch {
+		case r == 'r':
+			ui.ghostVertical = !ui.ghostVertical
+		case isConfirmKey(r):
+			ui.moveGhost(tilesNum)
+			ship, ok := board.StraightShip(ui.cursor.Pos(), tilesNum, ui.ghostVertical)
+			if ok && ui.canPlace(ship) {
+				ui.ShowError("")
+				return ship, true
+			}
+			ui.ShowError("The ship cannot be placed here")
+		}
+	}
+	ui.moveGhost(tilesNum)
+	return nil, false
+}
+
+// Displays the ghost of the ship of the given length at the cursor.
+func (ui *PlacementUI) moveGhost(tilesNum int) {
+	ui.ghost = ui.ghost[:0]
+	for i := 0; i < tilesNum; i++ {
+		c := ui.cursor.Pos().Add(i, 0)
+		if ui.ghostVertical {
+			c = ui.cursor.Pos().Add(0, i)
+		}
+		if c.Valid() {
+			ui.ghost = append(ui.ghost, c)
+		}
+	}
+	ui.render()
+}
+
+// Reports whether all the cells of the ship are free, i.e. neither occupied nor next to another ship.
+func (ui *PlacementUI) canPlace(ship []board.Coord) bool {
+	for _, c := range ship {
+		if !c.Valid() || ui.tiles[c.X][c.Y] != wGui.Empty {
+			return false
+		}
+	}
+	return true
+}
+
+// Displays the tiles on the board, with the ghost on top of them.
+// The ghost is displayed as a ship if it can be placed, and in red if it is out of bounds or touches another ship.
+func (ui *PlacementUI) render() {
+	states := ui.tiles
+	if len(ui.ghost) != 0 {
+		_, valid := board.StraightShip(ui.cursor.Pos(), len(ui.ghost), ui.ghostVertical)
+		ghostState := wGui.Ship
+		if !valid || !ui.canPlace(ui.ghost) {
+			ghostState = wGui.Hit
+		}
+		for _, c := range ui.ghost {
+			states[c.X][c.Y] = ghostState
+		}
+	}
+	ui.board.SetStates(states)
+}
+
+// Deletes the ship clicked on the board and returns its length.
+// Returns 0 if the deletion was cancelled, also by replacing the whole fleet.
 func (ui *PlacementUI) deleteShip(ctx context.Context) (int, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	ui.mu.Lock()
+	ui.cancelShip = cancel
+	version := ui.fleetVersion
+	ui.mu.Unlock()
 	for {
 		select {
 		case <-ctx.Done():
@@ -299,57 +566,65 @@ func (ui *PlacementUI) deleteShip(ctx context.Context) (int, error) {
 			if tile == "" {
 				continue
 			}
-			coords, err := ConvertCoords(tile)
+			coords, err := board.ParseCoord(tile)
 			if err != nil {
 				continue
 			}
-			state := ui.tiles[coords[0]][coords[1]]
-			if state != wGui.Ship {
-				continue
-			}
-			cluster, surroundings := getPlacement(coords[0], coords[1], ui.tiles)
-			newShipCoords := make([]string, len(ui.shipCoords))
-			bCopy := ui.tiles
-			copy(newShipCoords, ui.shipCoords)
-			for _, coords := range cluster {
-				ship, err := ConvertToString(coords[0], coords[1])
-				if err != nil {
-					return 0, fmt.Errorf("failed to convert coords: %w", err)
-				}
-				slices.Sort(newShipCoords)
-				pos, found := slices.BinarySearch(newShipCoords, ship)
-				if !found {
-					continue
-				}
-				newShipCoords = slices.Delete(newShipCoords, pos, pos+1)
-				bCopy[coords[0]][coords[1]] = wGui.Empty
-			}
-			intersection, err := ui.findIntersection(shipPlacement{cluster: cluster, surroundings: surroundings})
-			if err != nil {
-				return 0, fmt.Errorf("failed to find intersection: %w", err)
-			}
-			for _, coords := range surroundings {
-				bCopy[coords[0]][coords[1]] = wGui.Empty
-			}
-			for _, coords := range intersection {
-				bCopy[coords[0]][coords[1]] = wGui.Blocked
+			if n, err := ui.deleteShipAt(coords, version); n != 0 || err != nil {
+				return n, err
 			}
-			ui.shipCoords = newShipCoords
-			ui.tiles = bCopy
-			ui.board.SetStates(ui.tiles)
-			return len(cluster), nil
 		}
 	}
 }
 
-func (ui PlacementUI) findIntersection(ship shipPlacement) ([][2]int, error) {
+// Deletes the ship at the coordinates and returns its length. Returns 0 if there is no ship there, or the fleet was replaced
+// since the deletion started, in which case the context is cancelled already.
+func (ui *PlacementUI) deleteShipAt(coords board.Coord, version int) (int, error) {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+	if ui.fleetVersion != version || ui.tiles[coords.X][coords.Y] != wGui.Ship {
+		return 0, nil
+	}
+	grid := ui.grid()
+	cluster, surroundings := grid.Cluster(coords)
+	newShipCoords := make([]string, len(ui.shipCoords))
+	bCopy := ui.tiles
+	copy(newShipCoords, ui.shipCoords)
+	for _, coords := range cluster {
+		ship := coords.String()
+		slices.Sort(newShipCoords)
+		pos, found := slices.BinarySearch(newShipCoords, ship)
+		if !found {
+			continue
+		}
+		newShipCoords = slices.Delete(newShipCoords, pos, pos+1)
+		bCopy[coords.X][coords.Y] = wGui.Empty
+	}
+	intersection, err := ui.findIntersection(shipPlacement{cluster: cluster, surroundings: surroundings})
+	if err != nil {
+		return 0, fmt.Errorf("failed to find intersection: %w", err)
+	}
+	for _, coords := range surroundings {
+		bCopy[coords.X][coords.Y] = wGui.Empty
+	}
+	for _, coords := range intersection {
+		bCopy[coords.X][coords.Y] = wGui.Blocked
+	}
+	ui.shipCoords = newShipCoords
+	ui.tiles = bCopy
+	ui.render()
+	ui.changeShipCounter(fmt.Sprintf("%dship", len(cluster)), false)
+	return len(cluster), nil
+}
+
+func (ui *PlacementUI) findIntersection(ship shipPlacement) ([]board.Coord, error) {
 	ships, err := ui.getShips()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get ships: %w", err)
 	}
-	intersection := make([][2]int, 0)
+	intersection := make([]board.Coord, 0)
 	for _, s := range ships {
-		if equalCoordArrays(s.cluster, ship.cluster) {
+		if s.cluster.Equal(ship.cluster) {
 			continue
 		}
 		intersection = append(intersection, getIntersection(ship.surroundings, s.surroundings)...)
@@ -357,9 +632,9 @@ func (ui PlacementUI) findIntersection(ship shipPlacement) ([][2]int, error) {
 	return intersection, nil
 }
 
-func getIntersection(first, second [][2]int) [][2]int {
-	out := make([][2]int, 0)
-	bucket := map[[2]int]bool{}
+func getIntersection(first, second []board.Coord) []board.Coord {
+	out := make([]board.Coord, 0)
+	bucket := map[board.Coord]bool{}
 
 	for _, i := range first {
 		for _, j := range second {
@@ -372,23 +647,21 @@ func getIntersection(first, second [][2]int) [][2]int {
 	return out
 }
 
-func (ui PlacementUI) getShips() ([]shipPlacement, error) {
+func (ui *PlacementUI) getShips() ([]shipPlacement, error) {
 	sCopy := make([]string, len(ui.shipCoords))
 	copy(sCopy, ui.shipCoords)
 	slices.Sort(sCopy)
 	ships := make([]shipPlacement, 0)
+	grid := ui.grid()
 	for len(sCopy) != 0 {
 		tile := sCopy[0]
-		coords, err := ConvertCoords(tile)
+		coords, err := board.ParseCoord(tile)
 		if err != nil {
 			return nil, fmt.Errorf("failed to convert coords to num values: %w", err)
 		}
-		cluster, surroundings := getPlacement(coords[0], coords[1], ui.tiles)
+		cluster, surroundings := grid.Cluster(coords)
 		for _, coords := range cluster {
-			tile, err := ConvertToString(coords[0], coords[1])
-			if err != nil {
-				return nil, fmt.Errorf("failed to convert coords to string: %w", err)
-			}
+			tile := coords.String()
 			pos, found := slices.BinarySearch(sCopy, tile)
 			if !found {
 				continue
@@ -401,7 +674,7 @@ func (ui PlacementUI) getShips() ([]shipPlacement, error) {
 }
 
 func (ui *PlacementUI) Board() []string {
-	return ui.shipCoords
+	return ui.ShipCoords()
 }
 
 // func (ui *PlacementUI) deleteShipTile(lCoord, nCoord int) {
@@ -418,7 +691,7 @@ func (ui *PlacementUI) Board() []string {
 // 	}
 // }
 
-func (ui *PlacementUI) changeEmphasisAround(lCoord, nCoord int, remove bool) {
+func (ui *PlacementUI) changeEmphasisAround(c board.Coord, remove bool) {
 	var oldState, newState wGui.State
 	if remove {
 		oldState = wGui.Emphasis
@@ -428,81 +701,28 @@ func (ui *PlacementUI) changeEmphasisAround(lCoord, nCoord int, remove bool) {
 		newState = wGui.Emphasis
 	}
 
-	for i := -1; i < 2; i += 2 {
-		newLCoord := lCoord + i
-		newNCoord := nCoord + i
-		if newLCoord >= 0 && newLCoord <= 9 {
-			ui.replaceTile(newLCoord, nCoord, oldState, newState)
-		}
-		if newNCoord >= 0 && newNCoord <= 9 {
-			ui.replaceTile(lCoord, newNCoord, oldState, newState)
-		}
+	for _, near := range c.Adjacent() {
+		ui.replaceTile(near, oldState, newState)
 	}
 }
 
-func (ui *PlacementUI) replaceTile(lCoord, nCoord int, old, new wGui.State) {
-	state := ui.tiles[lCoord][nCoord]
+func (ui *PlacementUI) replaceTile(c board.Coord, old, new wGui.State) {
+	state := ui.tiles[c.X][c.Y]
 	if state != old {
 		return
 	}
-	ui.tiles[lCoord][nCoord] = new
-}
-
-func getPlacement(lCoord, nCoord int, states [10][10]wGui.State) (statusCluster, surroundings [][2]int) {
-	toVisit := [][2]int{{lCoord, nCoord}}
-	statusCluster = make([][2]int, 0)
-	surroundings = make([][2]int, 0)
-	for length := len(toVisit); len(toVisit) > 0; length = len(toVisit) {
-		n := length - 1
-		coords := toVisit[n]
-		x := coords[0]
-		y := coords[1]
-		toVisit = toVisit[:n]
-		state := states[x][y]
-
-		included := false
-		for _, c := range statusCluster {
-			if c[0] == coords[0] && c[1] == coords[1] {
-				included = true
-				break
-			}
-		}
-		if !included {
-			statusCluster = append(statusCluster, coords)
-		}
-		for i := -1; i < 2; i++ {
-			for j := -1; j < 2; j++ {
-				if (j == 0 && i == 0) || x+i < 0 || x+i > 9 || y+j < 0 || y+j > 9 {
-					continue
-				}
-				nearState := states[x+i][y+j]
-				if nearState == state {
-					c := [2]int{x + i, y + j}
-					if !slices.Contains(statusCluster, c) {
-						toVisit = append(toVisit, c)
-					}
-					continue
-				}
-				surroundings = append(surroundings, [2]int{x + i, y + j})
-			}
-		}
-	}
-	return
+	ui.tiles[c.X][c.Y] = new
 }
 
-func equalCoordArrays(first, second [][2]int) bool {
-	for _, elem := range first {
-		elemPresent := false
-		for _, elem2 := range second {
-			if elem[0] == elem2[0] && elem[1] == elem2[1] {
-				elemPresent = true
-			}
-		}
-		if !elemPresent {
-			return false
+// Returns the grid with the ships placed so far.
+func (ui *PlacementUI) grid() board.Grid {
+	grid := board.Grid{}
+	for _, c := range board.AllCoords() {
+		if ui.tiles[c.X][c.Y] == wGui.Ship {
+			grid.Set(c, board.Occupied)
 		}
 	}
-	return true
+	return grid
 }
 
 func (ui *PlacementUI) SetBtnListen(ctx context.Context) string {
@@ -510,5 +730,83 @@ func (ui *PlacementUI) SetBtnListen(ctx context.Context) string {
 }
 
 func (ui *PlacementUI) ShipCoords() []string {
-	return ui.shipCoords
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+	return slices.Clone(ui.shipCoords)
+}
+
+// Displays the error under the buttons. An empty message hides it.
+func (ui *PlacementUI) ShowError(message string) {
+	ui.errorTxt.SetFgColor(wGui.Red)
+	ui.errorTxt.SetText(message)
+}
+
+// Displays the message in place of the error, e.g. to confirm that the layout was saved.
+func (ui *PlacementUI) ShowInfo(message string) {
+	ui.errorTxt.SetFgColor(wGui.Green)
+	ui.errorTxt.SetText(message)
+}
+
+// Returns the name of the layout typed by the player.
+func (ui *PlacementUI) LayoutName() string {
+	return strings.TrimSpace(ui.layoutInput.GetText())
+}
+
+// Reports whether the list of saved layouts is displayed.
+func (ui *PlacementUI) LayoutsShown() bool {
+	return ui.layoutsOn
+}
+
+// Displays the list of the saved layouts. Clicking a layout makes `SetBtnListen` return its key, see `LayoutKey`.
+func (ui *PlacementUI) ShowLayouts(names []string) {
+	ui.clearLayouts()
+	areaMap := make(map[string]wGui.Physical, len(ui.btnMapping)+layoutListSize)
+	for key, btn := range ui.btnMapping {
+		areaMap[key] = btn
+	}
+	if len(names) == 0 {
+		ui.layoutsTxt.SetText("No layouts were saved yet")
+	} else {
+		ui.layoutsTxt.SetText("Click a layout to load it")
+	}
+	ui.layoutsOn = true
+	cfg := wGui.NewButtonConfig()
+	cfg.Width = 24
+	cfg.Height = 1
+	cfg.BgColor = wGui.Grey
+	for i, name := range names[:min(len(names), layoutListSize)] {
+		row := NewRow([]*wGui.Button{wGui.NewButton(layoutListX, layoutListY+2+i*2, name, cfg)})
+		ui.controller.Draw(row.GetButtons()[0])
+		ui.layoutRows = append(ui.layoutRows, row)
+		areaMap[LayoutOpt(name)] = row
+	}
+	ui.btnsArea.SetClickablesOn(areaMap)
+	ui.controller.Draw(ui.btnsArea)
+}
+
+// Removes the list of saved layouts from the screen.
+func (ui *PlacementUI) HideLayouts() {
+	ui.clearLayouts()
+	ui.layoutsOn = false
+	ui.layoutsTxt.SetText("")
+	ui.btnsArea.SetClickablesOn(ui.btnMapping)
+	ui.controller.Draw(ui.btnsArea)
+}
+
+func (ui *PlacementUI) clearLayouts() {
+	ui.controller.Remove(ui.btnsArea)
+	for _, row := range ui.layoutRows {
+		ui.controller.Remove(row.GetButtons()[0])
+	}
+	ui.layoutRows = nil
+}
+
+// Returns the key returned by `SetBtnListen` when the row of the layout with the name is clicked.
+func LayoutOpt(name string) string {
+	return layoutKeyPrefix + name
+}
+
+// Returns the name of the layout if the key returned by `SetBtnListen` belongs to a row of the layout list.
+func LayoutKey(key string) (string, bool) {
+	return strings.CutPrefix(key, layoutKeyPrefix)
 }
@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"battleship_client/board"
+	"context"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	gui "github.com/RostKoff/warships-gui/v2"
+)
+
+// Creates the placement screen with only the elements changed by placing the ships, so it can be used without a terminal.
+func newTestPlacementUI() *PlacementUI {
+	ui := &PlacementUI{
+		board:       gui.NewBoard(2, 2, nil),
+		ships:       make(map[string]Row),
+		setShipsBtn: gui.NewButton(1, 24, "Random configuration", nil),
+		errorTxt:    gui.NewText(1, 26, "", nil),
+		keyInput:    gui.NewTextField(50, 20, 20, 1, nil),
+	}
+	for _, c := range board.AllCoords() {
+		ui.tiles[c.X][c.Y] = gui.Empty
+	}
+	return ui
+}
+
+// Waits until the ship being placed or deleted can be cancelled.
+func waitForShip(t *testing.T, ui *PlacementUI) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second * 5)
+	for time.Now().Before(deadline) {
+		ui.mu.Lock()
+		started := ui.cancelShip != nil
+		ui.mu.Unlock()
+		if started {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("timed out waiting for the ship to be placed")
+}
+
+// Checks that the board shows exactly the ships of the fleet.
+func checkFleet(t *testing.T, ui *PlacementUI, fleet []board.Ship) {
+	t.Helper()
+	want := board.FormatCoords(board.Cells(fleet))
+	got := ui.ShipCoords()
+	slices.Sort(want)
+	slices.Sort(got)
+	if !slices.Equal(got, want) {
+		t.Errorf("ship coords = %v, want %v", got, want)
+	}
+	ui.mu.Lock()
+	grid := ui.grid()
+	ui.mu.Unlock()
+	if tiles := board.FormatCoords(grid.Find(board.Occupied)); len(tiles) != board.FleetCells {
+		t.Errorf("board has %d ship tiles, want %d", len(tiles), board.FleetCells)
+	}
+}
+
+// Replaces the fleet while a ship is being placed or deleted. The pending ship is cancelled without restoring the old board.
+func TestPlacementUIReplaceFleet(t *testing.T) {
+	tests := []struct {
+		name string
+		ship func(ui *PlacementUI) bool
+	}{
+		{name: "placing", ship: func(ui *PlacementUI) bool { return ui.placeShip(4, context.Background()) }},
+		{name: "deleting", ship: func(ui *PlacementUI) bool {
+			n, err := ui.deleteShip(context.Background())
+			return n != 0 || err != nil
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ui := newTestPlacementUI()
+			done := make(chan bool)
+			go func() { done <- tt.ship(ui) }()
+			waitForShip(t, ui)
+
+			fleet := board.RandomFleet(rand.New(rand.NewSource(1)))
+			ui.SetShips(fleet)
+			select {
+			case changed := <-done:
+				if changed {
+					t.Errorf("the ship changed the board after the fleet was replaced")
+				}
+			case <-time.After(time.Second * 5):
+				t.Fatal("the ship was not cancelled when the fleet was replaced")
+			}
+			checkFleet(t, ui, fleet)
+		})
+	}
+}
+
+// Clicks the board and places ships while the fleet is randomized, so `go test -race` reports unguarded state.
+func TestPlacementUIConcurrentAccess(t *testing.T) {
+	ui := newTestPlacementUI()
+	// Released once all the goroutines are started, so their calls overlap.
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	run := func(f func(round int)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			for round := 0; round < 50; round++ {
+				f(round)
+			}
+		}()
+	}
+	// "Randomize" button.
+	rng := rand.New(rand.NewSource(1))
+	run(func(int) { ui.Randomize(rng) })
+	// Ship selected for placing and deleting, cancelled by the fleet or after a while.
+	run(func(round int) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+		if round%2 == 0 {
+			ui.placeShip(round%4+1, ctx)
+		} else {
+			ui.deleteShip(ctx)
+		}
+	})
+	// Clicks on the board.
+	run(func(round int) {
+		for _, c := range board.AllCoords() {
+			ui.mu.Lock()
+			version := ui.fleetVersion
+			ui.mu.Unlock()
+			if round%2 == 0 {
+				ui.BoardClick(c, true)
+			} else if _, err := ui.deleteShipAt(c, version); err != nil {
+				t.Errorf("deleteShipAt(%s) error: %s", c, err)
+			}
+		}
+	})
+	// Submitting the board.
+	run(func(int) {
+		if coords := ui.ShipCoords(); len(coords) > board.FleetCells {
+			t.Errorf("%d ship coords placed, want at most %d", len(coords), board.FleetCells)
+		}
+	})
+	close(start)
+	wg.Wait()
+
+	fleet := board.RandomFleet(rng)
+	ui.SetShips(fleet)
+	checkFleet(t, ui, fleet)
+}
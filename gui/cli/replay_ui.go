@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"battleship_client/history"
+	"context"
+	"fmt"
+	"strings"
+
+	wGui "github.com/RostKoff/warships-gui/v2"
+)
+
+const (
+	FirstMoveOpt = "firstMove"
+	PrevMoveOpt  = "prevMove"
+	PlayOpt      = "play"
+	NextMoveOpt  = "nextMove"
+	LastMoveOpt  = "lastMove"
+	JumpOpt      = "jump"
+)
+
+// Screen replaying a recorded game move by move on the same boards as the game screen.
+type ReplayUI struct {
+	Controller   *wGui.GUI
+	PBoard       *GameBoard
+	OppBoard     *GameBoard
+	game         history.Game
+	moves        []history.Event
+	moveText     *wGui.Text
+	accuracyText *wGui.Text
+	endText      *wGui.Text
+	errorText    *wGui.Text
+	playBtn      *wGui.Button
+	jumpInput    *wGui.TextField
+	btnArea      *wGui.HandleArea
+}
+
+// Creates and draws the replay of the game, positioned before the first move.
+func InitReplay(controller *wGui.GUI, game history.Game) *ReplayUI {
+	btnCfg := wGui.NewButtonConfig()
+	btnCfg.BgColor = wGui.Blue
+	labels := []struct{ key, text string }{
+		{FirstMoveOpt, "<<"},
+		{PrevMoveOpt, "< Back"},
+		{PlayOpt, "Play"},
+		{NextMoveOpt, "Next >"},
+		{LastMoveOpt, ">>"},
+	}
+	btnMapping := make(map[string]wGui.Physical, len(labels)+2)
+	drawables := make([]wGui.Drawable, 0)
+	var playBtn *wGui.Button
+	x := 79
+	for _, l := range labels {
+		btn := wGui.NewButton(x, 1, l.text, btnCfg)
+		w, _ := btn.Size()
+		x += w + 1
+		btnMapping[l.key] = btn
+		drawables = append(drawables, btn)
+		if l.key == PlayOpt {
+			playBtn = btn
+		}
+	}
+
+	jumpCfg := wGui.NewTextFieldConfig()
+	jumpCfg.UnfilledChar = '_'
+	jumpCfg.InputOn = true
+	jumpInput := wGui.NewTextField(79, 5, 6, 1, jumpCfg)
+	btnCfg.BgColor = wGui.Grey
+	jumpBtn := wGui.NewButton(87, 4, "Jump to move", btnCfg)
+	w, _ := jumpBtn.Size()
+	backBtn := wGui.NewButton(88+w, 4, "Go back", btnCfg)
+	btnMapping[JumpOpt] = jumpBtn
+	btnMapping[BackOpt] = backBtn
+	btnArea := wGui.NewHandleArea(btnMapping)
+
+	ui := ReplayUI{
+		Controller:   controller,
+		PBoard:       InitGameBoard(1, 5, nil),
+		OppBoard:     InitGameBoard(50, 5, nil),
+		game:         game,
+		moves:        game.Moves(),
+		moveText:     wGui.NewText(1, 1, "", nil),
+		accuracyText: wGui.NewText(1, 3, "", nil),
+		endText:      wGui.NewText(50, 1, fmt.Sprintf("Outcome: %s", outcomeLabel(game.Outcome)), nil),
+		errorText:    wGui.NewText(50, 3, "", nil),
+		playBtn:      playBtn,
+		jumpInput:    jumpInput,
+		btnArea:      btnArea,
+	}
+	ui.errorText.SetBgColor(wGui.Red)
+	ui.errorText.SetFgColor(wGui.White)
+	ui.PBoard.Nick.SetText(game.Nick)
+	ui.OppBoard.Nick.SetText(game.Opponent)
+	ui.PBoard.Desc.SetText(game.Description)
+	ui.OppBoard.Desc.SetText(game.OpponentDescription)
+
+	drawables = append(drawables,
+		ui.PBoard.Board,
+		ui.PBoard.Nick,
+		ui.PBoard.Desc,
+		ui.OppBoard.Board,
+		ui.OppBoard.Nick,
+		ui.OppBoard.Desc,
+		ui.moveText,
+		ui.accuracyText,
+		ui.endText,
+		ui.errorText,
+		jumpInput,
+		jumpBtn,
+		backBtn,
+		btnArea,
+	)
+	for _, drawable := range drawables {
+		controller.Draw(drawable)
+	}
+	ui.ShowMove(0)
+	return &ui
+}
+
+// Returns the number of moves of both players in the replayed game.
+func (ui *ReplayUI) Moves() int {
+	return len(ui.moves)
+}
+
+// Displays both boards as they were after the given number of moves, together with the last move and the player's accuracy.
+func (ui *ReplayUI) ShowMove(n int) {
+	n = max(0, min(n, len(ui.moves)))
+	player, opponent := ui.game.GridsAt(n)
+	ui.PBoard.SetGrid(player)
+	ui.OppBoard.SetGrid(opponent)
+
+	shots := make([]history.Event, 0)
+	for _, e := range ui.moves[:n] {
+		if e.Type == history.EventShot {
+			shots = append(shots, e)
+		}
+	}
+	hit, miss := shotCounts(shots)
+	ui.accuracyText.SetText(fmt.Sprintf("Accuracy: %s", formatAccuracy(hit, miss)))
+
+	if n == 0 {
+		ui.moveText.SetText(fmt.Sprintf("Move 0/%d", len(ui.moves)))
+		return
+	}
+	last := ui.moves[n-1]
+	shooter := ui.game.Nick
+	if last.Type == history.EventOppShot {
+		shooter = ui.game.Opponent
+	}
+	ui.moveText.SetText(fmt.Sprintf("Move %d/%d: %s fired at %s, %s", n, len(ui.moves), shooter, last.Coord, last.Result))
+}
+
+// Changes the label of the play button depending on whether the replay is playing.
+func (ui *ReplayUI) SetPlaying(playing bool) {
+	if playing {
+		ui.playBtn.SetText("Pause")
+	} else {
+		ui.playBtn.SetText("Play")
+	}
+}
+
+// Returns the move number typed by the player.
+func (ui *ReplayUI) JumpTarget() string {
+	return strings.TrimSpace(ui.jumpInput.GetText())
+}
+
+// Displays the error message, or hides it if the message is empty.
+func (ui *ReplayUI) ShowError(message string) {
+	ui.errorText.SetText(message)
+}
+
+// Listens for clicks on the replay buttons and returns the key of the clicked one.
+func (ui *ReplayUI) Listen(ctx context.Context) string {
+	return ui.btnArea.Listen(ctx)
+}
@@ -2,34 +2,49 @@ package cli
 
 import (
 	"battleship_client/api/client"
+	"battleship_client/profile"
 	"context"
+	"fmt"
 
 	wGui "github.com/RostKoff/warships-gui/v2"
 )
 
 type SettingsUI struct {
-	Controller *wGui.GUI
-	nameInput  *wGui.TextField
-	descInput  *wGui.TextField
-	startBtn   *wGui.Button
-	botBtn     *wGui.Button
-	refreshBtn *wGui.Button
-	BtnArea    *wGui.HandleArea
-	lobbyArea  *wGui.HandleArea
-	lobbyMap   map[string]Row
-	targetNick string
-	targetRow  *Row
-}
-
-// Creates and draws all the elements of the lobby.
-func InitSettings(controller *wGui.GUI, settings *client.GameSettings) *SettingsUI {
+	Controller  *wGui.GUI
+	nameInput   *wGui.TextField
+	descInput   *wGui.TextField
+	startBtn    *wGui.Button
+	botBtn      *wGui.Button
+	refreshBtn  *wGui.Button
+	autoplayBtn *wGui.Button
+	nickErr     *wGui.Text
+	descErr     *wGui.Text
+	BtnArea     *wGui.HandleArea
+	btnMapping  map[string]wGui.Physical
+	resumeBtn   *wGui.Button
+	lobbyArea   *wGui.HandleArea
+	lobbyMap    map[string]Row
+	targetNick  string
+	targetRow   *Row
+}
+
+// Creates and draws all the elements of the lobby. The inputs are filled from the player's profile.
+func InitSettings(controller *wGui.GUI, prof profile.Profile) *SettingsUI {
 	// Name Input
 	nameTxt := wGui.NewText(2, 1, "Enter name", nil)
 	nameInCfg := wGui.NewTextFieldConfig()
 	nameInCfg.UnfilledChar = '_'
 	nameInCfg.InputOn = true
 	nameIn := wGui.NewTextField(2, 2, 30, 1, nameInCfg)
-	nameIn.SetText(settings.Nick)
+	nameIn.SetText(prof.Nick)
+	nickErr := wGui.NewText(2, 3, "", nil)
+	nickErr.SetFgColor(wGui.Red)
+
+	// Autoplay toggle
+	autoplayCfg := wGui.NewButtonConfig()
+	autoplayCfg.Width = 24
+	autoplayCfg.BgColor = wGui.Grey
+	autoplayBtn := wGui.NewButton(35, 1, "", autoplayCfg)
 
 	// Description Input
 	descTxt := wGui.NewText(2, 4, "Enter Description", nil)
@@ -37,7 +52,12 @@ func InitSettings(controller *wGui.GUI, settings *client.GameSettings) *Settings
 	descInCfg.UnfilledChar = '_'
 	descInCfg.InputOn = true
 	descIn := wGui.NewTextField(2, 5, 30, 5, descInCfg)
-	descIn.SetText(settings.Description)
+	descIn.SetText(prof.Description)
+	descErr := wGui.NewText(2, 10, "", nil)
+	descErr.SetFgColor(wGui.Red)
+
+	// Last game
+	lastGameTxt := wGui.NewText(35, 5, lastGameInfo(prof), nil)
 
 	// Action Buttons
 	btnCfg := wGui.NewButtonConfig()
@@ -54,15 +74,32 @@ func InitSettings(controller *wGui.GUI, settings *client.GameSettings) *Settings
 	w, _ = botBtn.Size()
 	btnCfg.BgColor = wGui.Grey
 	refreshBtn := wGui.NewButton(x+w+2, 11, "Refresh", btnCfg)
+	x, _ = refreshBtn.Position()
+	w, _ = refreshBtn.Size()
+	btnCfg.BgColor = wGui.Orange
+	leaderboardBtn := wGui.NewButton(x+w+2, 11, "Leaderboard", btnCfg)
+	x, _ = leaderboardBtn.Position()
+	w, _ = leaderboardBtn.Size()
+	btnCfg.BgColor = wGui.Grey
+	historyBtn := wGui.NewButton(x+w+2, 11, "History", btnCfg)
 
 	// Handle Area for buttons
 	btnMapping := map[string]wGui.Physical{
-		"botBtn":     botBtn,
-		"startBtn":   startBtn,
-		"refreshBtn": refreshBtn,
+		"botBtn":         botBtn,
+		"startBtn":       startBtn,
+		"refreshBtn":     refreshBtn,
+		"leaderboardBtn": leaderboardBtn,
+		"historyBtn":     historyBtn,
+		"autoplayBtn":    autoplayBtn,
 	}
 	btnArea := wGui.NewHandleArea(btnMapping)
 
+	// Resume button, displayed when a game in progress is found.
+	resumeCfg := wGui.NewButtonConfig()
+	resumeCfg.Width = 20
+	resumeCfg.BgColor = wGui.Red
+	resumeBtn := wGui.NewButton(61, 1, "Resume game", resumeCfg)
+
 	// Lobby
 	lCfg := wGui.NewButtonConfig()
 	lCfg.Width = 42
@@ -82,6 +119,12 @@ func InitSettings(controller *wGui.GUI, settings *client.GameSettings) *Settings
 		startBtn,
 		botBtn,
 		refreshBtn,
+		leaderboardBtn,
+		historyBtn,
+		autoplayBtn,
+		nickErr,
+		descErr,
+		lastGameTxt,
 		btnArea,
 		lobbyTxt,
 		lobbyArea,
@@ -93,14 +136,19 @@ func InitSettings(controller *wGui.GUI, settings *client.GameSettings) *Settings
 	}
 
 	return &SettingsUI{
-		Controller: controller,
-		nameInput:  nameIn,
-		descInput:  descIn,
-		startBtn:   startBtn,
-		botBtn:     botBtn,
-		refreshBtn: refreshBtn,
-		BtnArea:    btnArea,
-		lobbyArea:  lobbyArea,
+		Controller:  controller,
+		nameInput:   nameIn,
+		descInput:   descIn,
+		startBtn:    startBtn,
+		botBtn:      botBtn,
+		refreshBtn:  refreshBtn,
+		autoplayBtn: autoplayBtn,
+		nickErr:     nickErr,
+		descErr:     descErr,
+		BtnArea:     btnArea,
+		btnMapping:  btnMapping,
+		resumeBtn:   resumeBtn,
+		lobbyArea:   lobbyArea,
 	}
 }
 
@@ -176,6 +224,15 @@ func (ui *SettingsUI) ToggleOpponent(nick string) {
 	ui.targetRow = &row
 }
 
+// Listens for a click on the buttons and returns the key of the clicked one.
+func (ui *SettingsUI) BtnListen(ctx context.Context) string {
+	return ui.BtnArea.Listen(ctx)
+}
+
+func (ui *SettingsUI) Log(format string, args ...any) {
+	ui.Controller.Log(format, args...)
+}
+
 func (ui *SettingsUI) TargetNick() string {
 	return ui.targetNick
 }
@@ -187,3 +244,53 @@ func (ui *SettingsUI) Nick() string {
 func (ui *SettingsUI) Desc() string {
 	return ui.descInput.GetText()
 }
+
+// Displays the name of the strategy that plays instead of the player. Empty name means manual play.
+func (ui *SettingsUI) SetAutoplay(strategy string) {
+	if strategy == "" {
+		ui.autoplayBtn.SetText("Autoplay: off")
+		ui.autoplayBtn.SetBgColor(wGui.Grey)
+		return
+	}
+	ui.autoplayBtn.SetText(fmt.Sprintf("Autoplay: %s", strategy))
+	ui.autoplayBtn.SetBgColor(wGui.Blue)
+}
+
+// Displays the button that resumes the game in progress. Its clicks are returned by `BtnListen` as "resumeBtn".
+func (ui *SettingsUI) ShowResume() {
+	areaMap := make(map[string]wGui.Physical, len(ui.btnMapping)+1)
+	for key, btn := range ui.btnMapping {
+		areaMap[key] = btn
+	}
+	areaMap["resumeBtn"] = ui.resumeBtn
+	ui.Controller.Remove(ui.BtnArea)
+	ui.BtnArea.SetClickablesOn(areaMap)
+	ui.Controller.Draw(ui.resumeBtn)
+	ui.Controller.Draw(ui.BtnArea)
+}
+
+// Displays the errors of the nick and the description under their inputs. Nil errors hide the messages.
+func (ui *SettingsUI) ShowInputErrors(nickErr error, descErr error) {
+	ui.nickErr.SetText(errorText(nickErr))
+	ui.descErr.SetText(errorText(descErr))
+}
+
+func errorText(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// Returns the description of the last game started by the player, or an empty string if there was none.
+func lastGameInfo(prof profile.Profile) string {
+	switch {
+	case prof.Mode == profile.ModeBot:
+		return "Last game: against bot"
+	case prof.Mode == profile.ModeOnline && prof.LastOpponent != "":
+		return fmt.Sprintf("Last game: against %s", prof.LastOpponent)
+	case prof.Mode == profile.ModeOnline:
+		return "Last game: online"
+	}
+	return ""
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"battleship_client/engine"
+	"battleship_client/history"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	wGui "github.com/RostKoff/warships-gui/v2"
+)
+
+const (
+	RematchOpt = "rematch"
+	NewGameOpt = "newGame"
+	QuitOpt    = "quit"
+)
+
+type SummaryUI struct {
+	Controller *wGui.GUI
+	btnArea    *wGui.HandleArea
+}
+
+// Creates and draws the summary of the finished game with both boards and the buttons that choose what to do next.
+func InitSummary(controller *wGui.GUI, summary engine.Summary) *SummaryUI {
+	outcomeTxt := wGui.NewText(1, 1, summaryOutcome(summary.Outcome), nil)
+	switch summary.Outcome {
+	case history.OutcomeWin:
+		outcomeTxt.SetFgColor(wGui.Green)
+	default:
+		outcomeTxt.SetFgColor(wGui.Red)
+	}
+	statsTxt := wGui.NewText(1, 3, summaryStats(summary), nil)
+
+	rematchCfg := wGui.NewButtonConfig()
+	rematchCfg.BgColor = wGui.Green
+	rematchBtn := wGui.NewButton(50, 1, "Rematch same opponent", rematchCfg)
+	w, _ := rematchBtn.Size()
+	newGameCfg := wGui.NewButtonConfig()
+	newGameCfg.BgColor = wGui.Blue
+	newGameBtn := wGui.NewButton(51+w, 1, "New game", newGameCfg)
+	w2, _ := newGameBtn.Size()
+	quitCfg := wGui.NewButtonConfig()
+	quitCfg.BgColor = wGui.Red
+	quitBtn := wGui.NewButton(52+w+w2, 1, "Quit", quitCfg)
+	btnArea := wGui.NewHandleArea(map[string]wGui.Physical{
+		RematchOpt: rematchBtn,
+		NewGameOpt: newGameBtn,
+		QuitOpt:    quitBtn,
+	})
+
+	pBoard := InitGameBoard(1, 5, nil)
+	pBoard.SetGrid(summary.PlayerBoard)
+	pBoard.Nick.SetText(summary.Nick)
+	oppBoard := InitGameBoard(50, 5, nil)
+	oppBoard.SetGrid(summary.OpponentBoard)
+	oppBoard.Nick.SetText(summary.Opponent)
+
+	drawables := []wGui.Drawable{
+		outcomeTxt,
+		statsTxt,
+		rematchBtn,
+		newGameBtn,
+		quitBtn,
+		btnArea,
+		pBoard.Board,
+		pBoard.Nick,
+		oppBoard.Board,
+		oppBoard.Nick,
+	}
+	for _, drawable := range drawables {
+		controller.Draw(drawable)
+	}
+	return &SummaryUI{
+		Controller: controller,
+		btnArea:    btnArea,
+	}
+}
+
+// Listens for a click on the buttons and returns the key of the clicked one, e.g. `RematchOpt`.
+func (ui *SummaryUI) Listen(ctx context.Context) string {
+	return ui.btnArea.Listen(ctx)
+}
+
+func summaryOutcome(outcome string) string {
+	switch outcome {
+	case history.OutcomeWin:
+		return "You won!"
+	case history.OutcomeLose:
+		return "You lose!"
+	case history.OutcomeSessionLost:
+		return "Game session is lost!"
+	}
+	return outcomeLabel(outcome)
+}
+
+// Returns the statistics of the player's shots in a single line.
+func summaryStats(summary engine.Summary) string {
+	columns := []string{
+		fmt.Sprintf("Shots: %d", summary.Shots),
+		fmt.Sprintf("Hits: %d", summary.Hits),
+		fmt.Sprintf("Accuracy: %s", formatAccuracy(float64(summary.Hits), float64(summary.Shots-summary.Hits))),
+		fmt.Sprintf("Longest streak: %d", summary.LongestStreak),
+		fmt.Sprintf("Turns: %d", summary.Turns),
+		fmt.Sprintf("Duration: %s", summary.Duration.Round(time.Second)),
+	}
+	return strings.Join(columns, "   ")
+}
@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"battleship_client/engine"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSummaryStats(t *testing.T) {
+	tests := []struct {
+		name    string
+		summary engine.Summary
+		want    []string
+	}{
+		{
+			name:    "no shots",
+			summary: engine.Summary{},
+			want:    []string{"Shots: 0", "Hits: 0", "Accuracy: -", "Longest streak: 0", "Turns: 0"},
+		},
+		{
+			name:    "finished game",
+			summary: engine.Summary{Shots: 8, Hits: 3, LongestStreak: 2, Turns: 5, Duration: time.Minute + 1400*time.Millisecond},
+			want:    []string{"Shots: 8", "Hits: 3", "Accuracy: 37.50", "Longest streak: 2", "Turns: 5", "Duration: 1m1s"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := summaryStats(tt.summary)
+			for _, want := range tt.want {
+				if !strings.Contains(stats, want) {
+					t.Errorf("summaryStats() = %q, want %q in it", stats, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,316 @@
+// Package fake provides in-memory views of the client that are driven through channels instead of a terminal,
+// so the game flow can be run in tests.
+package fake
+
+import (
+	"battleship_client/board"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Game screen that keeps the displayed texts and boards in memory.
+// The player's shots and button clicks are sent to the `Shots` and `Buttons` channels.
+type GameView struct {
+	// Coordinates returned by `ListenForShot`.
+	Shots chan string
+	// Keys returned by `BtnListen`, e.g. `cli.AbandonOpt`.
+	Buttons chan string
+
+	mu       sync.Mutex
+	logs     []string
+	waiting  bool
+	shown    bool
+	turn     string
+	timer    string
+	end      string
+	errMsg   string
+	nicks    [2]string
+	descs    [2]string
+	pGrid    board.Grid
+	oppGrid  board.Grid
+	hit      int
+	miss     int
+	accuracy string
+	hintOn   bool
+	failures int
+	lastPoll time.Time
+	left     time.Duration
+}
+
+func NewGameView() *GameView {
+	return &GameView{
+		Shots:   make(chan string),
+		Buttons: make(chan string),
+	}
+}
+
+func (v *GameView) Log(format string, args ...any) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.logs = append(v.logs, fmt.Sprintf(format, args...))
+}
+
+func (v *GameView) ShowWaiting() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.waiting = true
+}
+
+func (v *GameView) Show() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.waiting = false
+	v.shown = true
+}
+
+func (v *GameView) DrawShips(coords []string) error {
+	ships, err := board.ParseCoords(coords)
+	if err != nil {
+		return fmt.Errorf("failed to convert coords: %w", err)
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for _, c := range ships {
+		v.pGrid.Set(c, board.Occupied)
+	}
+	return nil
+}
+
+func (v *GameView) DrawNicks(pNick string, oppNick string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.nicks = [2]string{pNick, oppNick}
+}
+
+func (v *GameView) DrawDescriptions(pDesc string, oppDesc string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.descs = [2]string{pDesc, oppDesc}
+}
+
+func (v *GameView) SetTurn(text string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.turn = text
+}
+
+func (v *GameView) SetTimer(text string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.timer = text
+}
+
+func (v *GameView) SetCountdown(left time.Duration) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.left = left
+	v.timer = fmt.Sprintf("Time: %d", (left+time.Second-1)/time.Second)
+}
+
+// Returns the time left in the player's turn that was displayed last.
+func (v *GameView) Countdown() time.Duration {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.left
+}
+
+func (v *GameView) SetEnd(text string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.end = text
+}
+
+func (v *GameView) SetConnection(failures int, lastPoll time.Time) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.failures = failures
+	v.lastPoll = lastPoll
+}
+
+// Returns the number of failed requests in a row and the time of the last status that were displayed.
+func (v *GameView) Connection() (int, time.Time) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.failures, v.lastPoll
+}
+
+func (v *GameView) ShowError(message string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.errMsg = message
+}
+
+// Marks the opponent's shots on the player's board in the same way as the terminal view.
+func (v *GameView) HandleOppShots(pShips []string, oppShots []string) error {
+	ships, err := board.ParseCoords(pShips)
+	if err != nil {
+		return fmt.Errorf("failed to convert ship coords: %w", err)
+	}
+	shots, err := board.ParseCoords(oppShots)
+	if err != nil {
+		return fmt.Errorf("failed to convert coords: %w", err)
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for _, c := range shots {
+		cell := board.Miss
+		if slices.Contains(ships, c) {
+			cell = board.Hit
+		}
+		v.pGrid.Set(c, cell)
+	}
+	return nil
+}
+
+// Marks the player's shot on the opponent's board. Like the terminal view, only hits and misses are counted.
+func (v *GameView) HandlePShot(fireResponse string, coord string) error {
+	c, err := board.ParseCoord(coord)
+	if err != nil {
+		return fmt.Errorf("failed to convert coords: %w", err)
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if _, err := v.oppGrid.ApplyResult(c, fireResponse); err != nil {
+		return fmt.Errorf("unknown response: %w", err)
+	}
+	switch fireResponse {
+	case board.ResultHit:
+		v.hit++
+	case board.ResultMiss:
+		v.miss++
+	}
+	return nil
+}
+
+func (v *GameView) RestoreOppBoard(grid board.Grid, hit int, miss int) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.oppGrid = grid
+	v.hit = hit
+	v.miss = miss
+}
+
+func (v *GameView) CalculateAccuracy() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.hit+v.miss == 0 {
+		v.accuracy = "-"
+		return
+	}
+	v.accuracy = fmt.Sprintf("%.2f", float64(v.hit)/float64(v.hit+v.miss)*100)
+}
+
+func (v *GameView) OppGrid() board.Grid {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.oppGrid
+}
+
+func (v *GameView) ToggleHint() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.hintOn = !v.hintOn
+}
+
+// Returns the next coordinate sent to `Shots`, or an empty one when the context is done.
+func (v *GameView) ListenForShot(ctx context.Context) (string, error) {
+	select {
+	case <-ctx.Done():
+		return "", nil
+	case coord := <-v.Shots:
+		return coord, nil
+	}
+}
+
+// Returns the next key sent to `Buttons`, or an empty one when the context is done.
+func (v *GameView) BtnListen(ctx context.Context) string {
+	select {
+	case <-ctx.Done():
+		return ""
+	case key := <-v.Buttons:
+		return key
+	}
+}
+
+// Returns the messages logged so far.
+func (v *GameView) Logs() []string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return slices.Clone(v.logs)
+}
+
+// Reports whether the waiting message is displayed.
+func (v *GameView) Waiting() bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.waiting
+}
+
+// Reports whether the boards were displayed.
+func (v *GameView) Shown() bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.shown
+}
+
+func (v *GameView) Turn() string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.turn
+}
+
+func (v *GameView) Timer() string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.timer
+}
+
+func (v *GameView) End() string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.end
+}
+
+// Returns the error message that is displayed, or an empty string if it is hidden.
+func (v *GameView) Error() string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.errMsg
+}
+
+// Returns the nicks of the player and the opponent.
+func (v *GameView) Nicks() (string, string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.nicks[0], v.nicks[1]
+}
+
+// Returns the descriptions of the player and the opponent.
+func (v *GameView) Descriptions() (string, string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.descs[0], v.descs[1]
+}
+
+// Returns the player's board with their ships and the opponent's shots.
+func (v *GameView) PlayerGrid() board.Grid {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.pGrid
+}
+
+// Returns the counted hits and misses and the accuracy displayed after the last shot.
+func (v *GameView) Accuracy() (hit int, miss int, accuracy string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.hit, v.miss, v.accuracy
+}
+
+func (v *GameView) HintOn() bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.hintOn
+}
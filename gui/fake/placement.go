@@ -0,0 +1,125 @@
+package fake
+
+import (
+	"battleship_client/board"
+	"context"
+	"math/rand"
+	"slices"
+	"sync"
+)
+
+// Placement screen that keeps the placed ships and the displayed messages in memory.
+// Button clicks are sent to the `Buttons` channel.
+type PlacementView struct {
+	// Keys returned by `SetBtnListen`, e.g. `cli.PlacementOpt`.
+	Buttons chan string
+
+	mu         sync.Mutex
+	coords     []string
+	layoutName string
+	layouts    []string
+	layoutsOn  bool
+	message    string
+	isError    bool
+}
+
+func NewPlacementView() *PlacementView {
+	return &PlacementView{Buttons: make(chan string)}
+}
+
+func (v *PlacementView) SetBtnListen(ctx context.Context) string {
+	select {
+	case <-ctx.Done():
+		return ""
+	case key := <-v.Buttons:
+		return key
+	}
+}
+
+// The ships are placed with `SetShipCoords`, so there are no clicks on the board to handle.
+func (v *PlacementView) Listen(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (v *PlacementView) Randomize(rng *rand.Rand) {
+	v.SetShips(board.RandomFleet(rng))
+}
+
+func (v *PlacementView) ShipCoords() []string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return slices.Clone(v.coords)
+}
+
+// Replaces the placed ships, as if the player placed them on the board.
+func (v *PlacementView) SetShipCoords(coords []string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.coords = slices.Clone(coords)
+}
+
+func (v *PlacementView) SetShips(ships []board.Ship) {
+	v.SetShipCoords(board.FormatCoords(board.Cells(ships)))
+}
+
+func (v *PlacementView) ShowError(message string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.message = message
+	v.isError = true
+}
+
+func (v *PlacementView) ShowInfo(message string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.message = message
+	v.isError = false
+}
+
+// Returns the displayed message and whether it is an error.
+func (v *PlacementView) Message() (string, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.message, v.isError
+}
+
+func (v *PlacementView) LayoutName() string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.layoutName
+}
+
+// Sets the layout name, as if the player typed it.
+func (v *PlacementView) SetLayoutName(name string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.layoutName = name
+}
+
+func (v *PlacementView) LayoutsShown() bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.layoutsOn
+}
+
+func (v *PlacementView) ShowLayouts(names []string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.layouts = slices.Clone(names)
+	v.layoutsOn = true
+}
+
+func (v *PlacementView) HideLayouts() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.layouts = nil
+	v.layoutsOn = false
+}
+
+// Returns the names of the displayed layouts.
+func (v *PlacementView) Layouts() []string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return slices.Clone(v.layouts)
+}
@@ -0,0 +1,163 @@
+package fake
+
+import (
+	"battleship_client/api/client"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Settings screen that keeps the inputs and the lobby in memory.
+// Button clicks are sent to the `Buttons` channel and clicks in the lobby to `LobbyClicks`.
+type SettingsView struct {
+	// Keys returned by `BtnListen`, e.g. "startBtn".
+	Buttons chan string
+	// Nicks of the lobby rows clicked by the player.
+	LobbyClicks chan string
+
+	mu          sync.Mutex
+	logs        []string
+	nick        string
+	desc        string
+	targetNick  string
+	autoplay    string
+	resumeShown bool
+	nickErr     error
+	descErr     error
+	lobby       []client.LobbyGame
+}
+
+// Creates the view with the inputs filled with the nick and the description.
+func NewSettingsView(nick string, desc string) *SettingsView {
+	return &SettingsView{
+		Buttons:     make(chan string),
+		LobbyClicks: make(chan string),
+		nick:        nick,
+		desc:        desc,
+	}
+}
+
+func (v *SettingsView) Log(format string, args ...any) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.logs = append(v.logs, fmt.Sprintf(format, args...))
+}
+
+func (v *SettingsView) BtnListen(ctx context.Context) string {
+	select {
+	case <-ctx.Done():
+		return ""
+	case key := <-v.Buttons:
+		return key
+	}
+}
+
+func (v *SettingsView) Nick() string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.nick
+}
+
+func (v *SettingsView) Desc() string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.desc
+}
+
+// Fills the inputs, as if the player typed the nick and the description.
+func (v *SettingsView) SetInputs(nick string, desc string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.nick = nick
+	v.desc = desc
+}
+
+func (v *SettingsView) TargetNick() string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.targetNick
+}
+
+// Selects the opponent if they are in the lobby, or deselects them if they are already selected.
+func (v *SettingsView) ToggleOpponent(nick string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if !slices.ContainsFunc(v.lobby, func(g client.LobbyGame) bool { return g.Nick == nick }) {
+		return
+	}
+	if v.targetNick == nick {
+		v.targetNick = ""
+		return
+	}
+	v.targetNick = nick
+}
+
+func (v *SettingsView) SetAutoplay(strategy string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.autoplay = strategy
+}
+
+// Returns the name of the displayed autoplay strategy.
+func (v *SettingsView) Autoplay() string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.autoplay
+}
+
+func (v *SettingsView) ShowResume() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.resumeShown = true
+}
+
+// Reports whether the button that resumes the game is displayed.
+func (v *SettingsView) ResumeShown() bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.resumeShown
+}
+
+func (v *SettingsView) ShowInputErrors(nickErr error, descErr error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.nickErr = nickErr
+	v.descErr = descErr
+}
+
+// Returns the errors displayed under the nick and the description.
+func (v *SettingsView) InputErrors() (error, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.nickErr, v.descErr
+}
+
+func (v *SettingsView) DrawLobbyGames(lobbyGames []client.LobbyGame) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.lobby = slices.Clone(lobbyGames)
+}
+
+// Returns the games displayed in the lobby.
+func (v *SettingsView) Lobby() []client.LobbyGame {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return slices.Clone(v.lobby)
+}
+
+// Toggles the opponent whose nick is sent to `LobbyClicks`.
+func (v *SettingsView) ListenLobby(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+	case nick := <-v.LobbyClicks:
+		v.ToggleOpponent(nick)
+	}
+}
+
+// Returns the messages logged so far.
+func (v *SettingsView) Logs() []string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return slices.Clone(v.logs)
+}
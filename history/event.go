@@ -0,0 +1,47 @@
+// Package history records the course of every game to a local directory and reads the recorded games back.
+// Each game is stored as a separate file with one JSON event per line, written as the game goes on.
+package history
+
+import "time"
+
+type EventType string
+
+const (
+	// The game started. Holds the nicks and the player's board.
+	EventStart EventType = "start"
+	// The descriptions of both players were received.
+	EventDescriptions EventType = "descriptions"
+	// The player fired a shot.
+	EventShot EventType = "shot"
+	// The opponent fired a shot.
+	EventOppShot EventType = "opp_shot"
+	// The game ended. Holds the outcome.
+	EventEnd EventType = "end"
+)
+
+// Outcomes of a recorded game.
+const (
+	OutcomeWin         = "win"
+	OutcomeLose        = "lose"
+	OutcomeAbandoned   = "abandoned"
+	OutcomeSessionLost = "session_lost"
+)
+
+// Single entry of the game log. Only the fields relevant for the type of the event are set.
+type Event struct {
+	Time time.Time `json:"time"`
+	Type EventType `json:"type"`
+	// Set for the shot events. The result is one of the results of the shot, e.g. `board.ResultHit`.
+	Coord  string `json:"coord,omitempty"`
+	Result string `json:"result,omitempty"`
+	// Set for the start event.
+	Nick       string   `json:"nick,omitempty"`
+	Opponent   string   `json:"opponent,omitempty"`
+	Board      []string `json:"board,omitempty"`
+	AgainstBot bool     `json:"wpbot,omitempty"`
+	// Set for the descriptions event.
+	Description         string `json:"desc,omitempty"`
+	OpponentDescription string `json:"opp_desc,omitempty"`
+	// Set for the end event.
+	Outcome string `json:"outcome,omitempty"`
+}
@@ -0,0 +1,204 @@
+package history
+
+import (
+	"battleship_client/board"
+	"battleship_client/xdg"
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Course of a single recorded game.
+type Game struct {
+	// Name of the file without the extension. Unique for every game.
+	ID                  string
+	Path                string
+	StartedAt           time.Time
+	EndedAt             time.Time
+	Nick                string
+	Opponent            string
+	Description         string
+	OpponentDescription string
+	AgainstBot          bool
+	// Coordinates of the player's ships.
+	Board []string
+	// Empty if the game did not end properly, e.g. the client was closed.
+	Outcome string
+	// All the events in the order they happened.
+	Events []Event
+}
+
+// Returns the default directory with the recorded games.
+func DefaultDir() (string, error) {
+	dir, err := xdg.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "history"), nil
+}
+
+// Loads all the games recorded in the directory, the most recent first.
+// The files that cannot be loaded are skipped, and their errors are returned separately, so one corrupt file does not hide the other games.
+// A missing directory means no games were recorded yet.
+func List(dir string) (games []Game, skipped []error, err error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read history directory: %w", err)
+	}
+	games = make([]Game, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != fileExt {
+			continue
+		}
+		game, err := Load(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			skipped = append(skipped, err)
+			continue
+		}
+		games = append(games, game)
+	}
+	slices.SortFunc(games, func(a, b Game) int {
+		return b.StartedAt.Compare(a.StartedAt)
+	})
+	return games, skipped, nil
+}
+
+// Loads the game recorded in the file.
+// The last event may be cut off if the client was closed while writing it, so an invalid last line is ignored.
+func Load(path string) (Game, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Game{}, fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer file.Close()
+	game := Game{
+		ID:   strings.TrimSuffix(filepath.Base(path), fileExt),
+		Path: path,
+	}
+	scanner := bufio.NewScanner(file)
+	// Error of the previous line, which is only a problem if it is not the last one.
+	var invalid error
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		if invalid != nil {
+			return Game{}, invalid
+		}
+		e := Event{}
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			invalid = fmt.Errorf("invalid event in %s at line %d: %w", path, line, err)
+			continue
+		}
+		game.apply(e)
+	}
+	if err := scanner.Err(); err != nil {
+		return Game{}, fmt.Errorf("failed to read history file: %w", err)
+	}
+	return game, nil
+}
+
+func (g *Game) apply(e Event) {
+	switch e.Type {
+	case EventStart:
+		g.StartedAt = e.Time
+		g.Nick = e.Nick
+		g.Opponent = e.Opponent
+		g.Board = e.Board
+		g.AgainstBot = e.AgainstBot
+	case EventDescriptions:
+		g.Description = e.Description
+		g.OpponentDescription = e.OpponentDescription
+	case EventEnd:
+		g.EndedAt = e.Time
+		g.Outcome = e.Outcome
+	}
+	g.Events = append(g.Events, e)
+}
+
+// Returns the player's shots in the order they were fired.
+func (g Game) Shots() []Event {
+	return g.eventsOf(EventShot)
+}
+
+// Returns the opponent's shots in the order they were fired.
+func (g Game) OpponentShots() []Event {
+	return g.eventsOf(EventOppShot)
+}
+
+func (g Game) eventsOf(t EventType) []Event {
+	out := make([]Event, 0)
+	for _, e := range g.Events {
+		if e.Type == t {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// Returns the shots of both players in the order they were fired.
+func (g Game) Moves() []Event {
+	out := make([]Event, 0)
+	for _, e := range g.Events {
+		if e.Type == EventShot || e.Type == EventOppShot {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// Returns both boards as they were after the given number of moves.
+// The player's board holds the ships and the opponent's shots at them,
+// the opponent's board holds what was known from the results of the player's shots.
+func (g Game) GridsAt(moves int) (player board.Grid, opponent board.Grid) {
+	for _, coord := range g.Board {
+		if c, err := board.ParseCoord(coord); err == nil {
+			player.Set(c, board.Occupied)
+		}
+	}
+	for i, e := range g.Moves() {
+		if i >= moves {
+			break
+		}
+		if e.Type == EventShot {
+			ApplyShot(&opponent, e)
+			continue
+		}
+		// The cells around the player's sunk ships are not marked during the game either.
+		if e.Result == board.ResultSunk {
+			e.Result = board.ResultHit
+		}
+		ApplyShot(&player, e)
+	}
+	return player, opponent
+}
+
+// Returns the player's board with all the opponent's shots marked on it.
+func (g Game) PlayerGrid() board.Grid {
+	player, _ := g.GridsAt(len(g.Events))
+	return player
+}
+
+// Returns the opponent's board as known from the results of all the player's shots.
+func (g Game) OpponentGrid() board.Grid {
+	_, opponent := g.GridsAt(len(g.Events))
+	return opponent
+}
+
+// Marks the result of the shot event on the grid. Sunk ships are surrounded with missed cells.
+// Events with invalid coordinates or results are skipped.
+func ApplyShot(grid *board.Grid, e Event) {
+	c, err := board.ParseCoord(e.Coord)
+	if err != nil {
+		return
+	}
+	grid.ApplyResult(c, e.Result)
+}
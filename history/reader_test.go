@@ -0,0 +1,94 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const (
+	startLine = `{"time":"2024-06-01T12:00:00Z","type":"start","nick":"alice","opponent":"bob","board":["A1"]}`
+	shotLine  = `{"time":"2024-06-01T12:00:05Z","type":"shot","coord":"E5","result":"miss"}`
+	endLine   = `{"time":"2024-06-01T12:01:00Z","type":"end","outcome":"win"}`
+)
+
+func writeGame(t *testing.T, dir, name string, lines ...string) string {
+	t.Helper()
+	path := filepath.Join(dir, name+fileExt)
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name        string
+		lines       []string
+		wantErr     bool
+		wantShots   int
+		wantOutcome string
+	}{
+		{name: "complete game", lines: []string{startLine, shotLine, endLine}, wantShots: 1, wantOutcome: OutcomeWin},
+		{name: "empty lines", lines: []string{startLine, "", shotLine, ""}, wantShots: 1},
+		{name: "game without the end", lines: []string{startLine, shotLine}, wantShots: 1},
+		{name: "truncated last line", lines: []string{startLine, shotLine, endLine[:20]}, wantShots: 1},
+		{name: "truncated last line with a newline", lines: []string{startLine, shotLine[:30], ""}},
+		{name: "invalid line in the middle", lines: []string{startLine, shotLine[:30], endLine}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeGame(t, t.TempDir(), "20240601-120000.000", tt.lines...)
+			game, err := Load(path)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Load() error = nil, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error: %s", err)
+			}
+			if game.ID != "20240601-120000.000" || game.Nick != "alice" {
+				t.Errorf("game %q of %q, want the game of alice", game.ID, game.Nick)
+			}
+			if len(game.Shots()) != tt.wantShots || game.Outcome != tt.wantOutcome {
+				t.Errorf("game has %d shots and outcome %q, want %d and %q", len(game.Shots()), game.Outcome, tt.wantShots, tt.wantOutcome)
+			}
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	dir := t.TempDir()
+	writeGame(t, dir, "older", startLine, endLine)
+	writeGame(t, dir, "newer", strings.Replace(startLine, "12:00:00", "13:00:00", 1), shotLine[:30])
+	corrupt := writeGame(t, dir, "corrupt", "not json", endLine)
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a game"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	games, skipped, err := List(dir)
+	if err != nil {
+		t.Fatalf("List() error: %s", err)
+	}
+	ids := make([]string, 0, len(games))
+	for _, g := range games {
+		ids = append(ids, g.ID)
+	}
+	if !slices.Equal(ids, []string{"newer", "older"}) {
+		t.Errorf("games = %v, want [newer older]", ids)
+	}
+	if len(skipped) != 1 || !strings.Contains(skipped[0].Error(), corrupt) {
+		t.Errorf("skipped = %v, want the error of %s", skipped, corrupt)
+	}
+}
+
+func TestListMissingDir(t *testing.T) {
+	games, skipped, err := List(filepath.Join(t.TempDir(), "history"))
+	if games != nil || skipped != nil || err != nil {
+		t.Errorf("List() = %v, %v, %v, want no games", games, skipped, err)
+	}
+}
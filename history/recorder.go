@@ -0,0 +1,163 @@
+package history
+
+import (
+	"battleship_client/board"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	fileExt    = ".jsonl"
+	fileLayout = "20060102-150405.000"
+)
+
+// Appends the events of a single game to its file. Every event is written immediately,
+// so the log is complete up to the last event even if the client is closed in the middle of the game.
+// All methods can be called on a nil recorder, in which case they do nothing.
+type Recorder struct {
+	mu   sync.Mutex
+	file *os.File
+	enc  *json.Encoder
+	// The player's ships and the opponent's shots at them, to work out the results of the opponent's shots.
+	own      board.Grid
+	oppShots int
+	now      func() time.Time
+}
+
+// Creates a file for a new game in the directory, creating the directory if needed.
+func NewRecorder(dir string) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create history directory: %w", err)
+	}
+	name := time.Now().Format(fileLayout) + fileExt
+	file, err := os.OpenFile(filepath.Join(dir, name), os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create history file: %w", err)
+	}
+	return &Recorder{file: file, enc: json.NewEncoder(file), now: time.Now}, nil
+}
+
+// Continues recording the game to the file created by `NewRecorder`, e.g. after the game is resumed.
+func OpenRecorder(path string) (*Recorder, error) {
+	game, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history file: %w", err)
+	}
+	player, _ := game.GridsAt(len(game.Events))
+	return &Recorder{
+		file:     file,
+		enc:      json.NewEncoder(file),
+		own:      player,
+		oppShots: len(game.OpponentShots()),
+		now:      time.Now,
+	}, nil
+}
+
+// Returns the path of the file the game is recorded to.
+func (r *Recorder) Path() string {
+	if r == nil {
+		return ""
+	}
+	return r.file.Name()
+}
+
+func (r *Recorder) Start(nick, opponent string, ships []string, againstBot bool) error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, coord := range ships {
+		if c, err := board.ParseCoord(coord); err == nil {
+			r.own.Set(c, board.Occupied)
+		}
+	}
+	return r.write(Event{Type: EventStart, Nick: nick, Opponent: opponent, Board: slices.Clone(ships), AgainstBot: againstBot})
+}
+
+func (r *Recorder) Descriptions(desc, oppDesc string) error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.write(Event{Type: EventDescriptions, Description: desc, OpponentDescription: oppDesc})
+}
+
+// Records the player's shot and the result returned by the server.
+func (r *Recorder) Shot(coord, result string) error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.write(Event{Type: EventShot, Coord: coord, Result: result})
+}
+
+// Records the opponent's shots that were not recorded yet. Takes all the opponent's shots from the game status.
+// The results are worked out from the player's board.
+func (r *Recorder) OpponentShots(shots []string) error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for ; r.oppShots < len(shots); r.oppShots++ {
+		coord := shots[r.oppShots]
+		if err := r.write(Event{Type: EventOppShot, Coord: coord, Result: r.oppShotResult(coord)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Records the outcome of the game and closes the file.
+func (r *Recorder) End(outcome string) error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	err := r.write(Event{Type: EventEnd, Outcome: outcome})
+	if closeErr := r.file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to close history file: %w", closeErr)
+	}
+	return err
+}
+
+// Marks the opponent's shot on the player's board and returns its result.
+func (r *Recorder) oppShotResult(coord string) string {
+	c, err := board.ParseCoord(coord)
+	if err != nil || !r.own.At(c).IsShip() {
+		return board.ResultMiss
+	}
+	r.own.Set(c, board.Hit)
+	for _, ship := range r.own.Ships() {
+		if !slices.Contains(ship, c) {
+			continue
+		}
+		for _, s := range ship {
+			if r.own.At(s) == board.Occupied {
+				return board.ResultHit
+			}
+		}
+	}
+	return board.ResultSunk
+}
+
+func (r *Recorder) write(e Event) error {
+	e.Time = r.now()
+	if err := r.enc.Encode(e); err != nil {
+		return fmt.Errorf("failed to write history event: %w", err)
+	}
+	return nil
+}
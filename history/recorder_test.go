@@ -0,0 +1,137 @@
+package history
+
+import (
+	"battleship_client/board"
+	"slices"
+	"testing"
+	"time"
+)
+
+// Ships of the player in the recorded games: a two-tile ship at A1-A2 and a single-tile ship at C1.
+var testShips = []string{"A1", "A2", "C1"}
+
+// Creates the recorder whose clock advances by a second with every event.
+func newTestRecorder(t *testing.T, dir string) *Recorder {
+	t.Helper()
+	r, err := NewRecorder(dir)
+	if err != nil {
+		t.Fatalf("NewRecorder() error: %s", err)
+	}
+	r.now = testClock()
+	return r
+}
+
+func testClock() func() time.Time {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	return func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+}
+
+func check(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRecorderRoundTrip(t *testing.T) {
+	r := newTestRecorder(t, t.TempDir())
+	check(t, r.Start("alice", "bob", testShips, true))
+	check(t, r.Descriptions("first game", "n/a"))
+	check(t, r.Shot("E5", board.ResultHit))
+	check(t, r.Shot("E6", board.ResultMiss))
+	check(t, r.OpponentShots([]string{"A1", "B5"}))
+	// The shots recorded before are not recorded again.
+	check(t, r.OpponentShots([]string{"A1", "B5", "A2", "C1"}))
+	check(t, r.End(OutcomeLose))
+
+	game, err := Load(r.Path())
+	if err != nil {
+		t.Fatalf("Load() error: %s", err)
+	}
+	if game.Nick != "alice" || game.Opponent != "bob" || !game.AgainstBot || !slices.Equal(game.Board, testShips) {
+		t.Errorf("start of the game = %s against %s, bot %t, board %v", game.Nick, game.Opponent, game.AgainstBot, game.Board)
+	}
+	if game.Description != "first game" || game.OpponentDescription != "n/a" {
+		t.Errorf("descriptions = %q and %q", game.Description, game.OpponentDescription)
+	}
+	if game.Outcome != OutcomeLose || !game.EndedAt.After(game.StartedAt) {
+		t.Errorf("end of the game = %q at %s, started at %s", game.Outcome, game.EndedAt, game.StartedAt)
+	}
+	if got := results(game.Shots()); !slices.Equal(got, []string{"E5 hit", "E6 miss"}) {
+		t.Errorf("shots = %v", got)
+	}
+	want := []string{"A1 hit", "B5 miss", "A2 sunk", "C1 sunk"}
+	if got := results(game.OpponentShots()); !slices.Equal(got, want) {
+		t.Errorf("opponent shots = %v, want %v", got, want)
+	}
+	if moves := len(game.Moves()); moves != 6 {
+		t.Errorf("moves = %d, want 6", moves)
+	}
+
+	// The player's sunk ships are shown as hit, without the missed cells around them.
+	player, opponent := game.GridsAt(4)
+	if player.At(coord("A1")) != board.Hit || player.At(coord("B5")) != board.Miss || player.At(coord("A2")) != board.Occupied {
+		t.Errorf("player's board after 4 moves is wrong")
+	}
+	if opponent.At(coord("E5")) != board.Hit || opponent.At(coord("E6")) != board.Miss {
+		t.Errorf("opponent's board after 4 moves is wrong")
+	}
+	if grid := game.PlayerGrid(); grid.At(coord("C1")) != board.Hit || grid.At(coord("B2")) != board.Empty {
+		t.Errorf("player's board at the end is wrong")
+	}
+}
+
+// Continues recording the game after the client was closed in the middle of it.
+func TestOpenRecorder(t *testing.T) {
+	r := newTestRecorder(t, t.TempDir())
+	check(t, r.Start("alice", "bob", testShips, false))
+	check(t, r.OpponentShots([]string{"A1"}))
+	check(t, r.file.Close())
+
+	r, err := OpenRecorder(r.Path())
+	if err != nil {
+		t.Fatalf("OpenRecorder() error: %s", err)
+	}
+	r.now = testClock()
+	check(t, r.OpponentShots([]string{"A1", "A2"}))
+	check(t, r.End(OutcomeWin))
+
+	game, err := Load(r.Path())
+	if err != nil {
+		t.Fatalf("Load() error: %s", err)
+	}
+	// The results of the new shots take the shots recorded before the client was closed into account.
+	if got := results(game.OpponentShots()); !slices.Equal(got, []string{"A1 hit", "A2 sunk"}) {
+		t.Errorf("opponent shots = %v", got)
+	}
+	if game.Outcome != OutcomeWin {
+		t.Errorf("outcome = %q, want %q", game.Outcome, OutcomeWin)
+	}
+}
+
+func TestNilRecorder(t *testing.T) {
+	var r *Recorder
+	check(t, r.Start("alice", "bob", testShips, false))
+	check(t, r.Shot("A1", board.ResultMiss))
+	check(t, r.OpponentShots([]string{"A1"}))
+	check(t, r.End(OutcomeWin))
+	if r.Path() != "" {
+		t.Errorf("Path() = %q, want empty", r.Path())
+	}
+}
+
+func results(events []Event) []string {
+	out := make([]string, 0, len(events))
+	for _, e := range events {
+		out = append(out, e.Coord+" "+e.Result)
+	}
+	return out
+}
+
+func coord(s string) board.Coord {
+	c, _ := board.ParseCoord(s)
+	return c
+}
@@ -0,0 +1,70 @@
+// Package layout stores the fleets placed by the player under names, so they can be reused in later games.
+// Layouts are kept in a compact text format that can be shared: 10 lines of 10 characters,
+// one line per row of the board, with '#' for ship tiles and '.' for empty tiles.
+package layout
+
+import (
+	"battleship_client/board"
+	"fmt"
+	"strings"
+)
+
+const (
+	shipChar  = '#'
+	emptyChar = '.'
+)
+
+// Returns the text format of the layout with ships on the given cells.
+func Format(coords []board.Coord) string {
+	grid := board.Grid{}
+	for _, c := range coords {
+		if c.Valid() {
+			grid.Set(c, board.Occupied)
+		}
+	}
+	sb := strings.Builder{}
+	for y := 0; y < board.Size; y++ {
+		for x := 0; x < board.Size; x++ {
+			if grid.At(board.Coord{X: x, Y: y}) == board.Occupied {
+				sb.WriteRune(shipChar)
+			} else {
+				sb.WriteRune(emptyChar)
+			}
+		}
+		sb.WriteRune('\n')
+	}
+	return sb.String()
+}
+
+// Reads the layout in the text format and returns the cells with ships.
+// Blank lines and whitespace around the lines are ignored. The fleet itself is not validated.
+func Parse(text string) ([]board.Coord, error) {
+	coords := make([]board.Coord, 0, board.FleetCells)
+	y := 0
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if y == board.Size {
+			return nil, fmt.Errorf("layout has more than %d rows", board.Size)
+		}
+		if len(line) != board.Size {
+			return nil, fmt.Errorf("row %d has %d tiles instead of %d", y+1, len(line), board.Size)
+		}
+		for x, r := range line {
+			switch r {
+			case shipChar:
+				coords = append(coords, board.Coord{X: x, Y: y})
+			case emptyChar:
+			default:
+				return nil, fmt.Errorf("row %d has invalid tile %q", y+1, r)
+			}
+		}
+		y++
+	}
+	if y != board.Size {
+		return nil, fmt.Errorf("layout has %d rows instead of %d", y, board.Size)
+	}
+	return coords, nil
+}
@@ -0,0 +1,81 @@
+package layout
+
+import (
+	"battleship_client/board"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestFormatParseRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 10; i++ {
+		coords := board.Cells(board.RandomFleet(rng))
+		text := Format(coords)
+		got, err := Parse(text)
+		if err != nil {
+			t.Fatalf("Parse(Format()) error: %s\n%s", err, text)
+		}
+		if !slices.Equal(sorted(got), sorted(coords)) {
+			t.Fatalf("Parse(Format()) = %v, want %v", got, coords)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	coords := []board.Coord{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 9, Y: 9}, {X: 10, Y: 0}}
+	want := "##........\n" + strings.Repeat("..........\n", 8) + ".........#\n"
+	if got := Format(coords); got != want {
+		t.Errorf("Format() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestParse(t *testing.T) {
+	empty := strings.Repeat("..........\n", 10)
+	tests := []struct {
+		name    string
+		text    string
+		want    []string
+		wantErr string
+	}{
+		{name: "empty board", text: empty, want: []string{}},
+		{name: "ships", text: "#.........\n#.........\n" + strings.Repeat("..........\n", 7) + ".........#", want: []string{"A1", "A2", "J10"}},
+		{name: "blank lines and whitespace", text: "\n  " + strings.ReplaceAll(empty, "\n", "  \n\n"), want: []string{}},
+		{name: "windows line endings", text: strings.ReplaceAll(empty, "\n", "\r\n"), want: []string{}},
+		{name: "too few rows", text: strings.Repeat("..........\n", 9), wantErr: "9 rows"},
+		{name: "too many rows", text: empty + "..........\n", wantErr: "more than 10 rows"},
+		{name: "short row", text: ".........\n" + strings.Repeat("..........\n", 9), wantErr: "row 1 has 9 tiles"},
+		{name: "long row", text: empty[:11] + "...........\n" + empty[22:], wantErr: "row 2 has 11 tiles"},
+		{name: "invalid tile", text: empty[:11] + "....x.....\n" + empty[22:], wantErr: `invalid tile 'x'`},
+		{name: "empty text", text: "", wantErr: "0 rows"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.text)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Parse() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() error: %s", err)
+			}
+			if formatted := board.FormatCoords(got); !slices.Equal(formatted, tt.want) {
+				t.Errorf("Parse() = %v, want %v", formatted, tt.want)
+			}
+		})
+	}
+}
+
+func sorted(coords []board.Coord) []board.Coord {
+	out := slices.Clone(coords)
+	slices.SortFunc(out, func(a, b board.Coord) int {
+		if a.Y != b.Y {
+			return a.Y - b.Y
+		}
+		return a.X - b.X
+	})
+	return out
+}
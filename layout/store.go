@@ -0,0 +1,132 @@
+package layout
+
+import (
+	"battleship_client/board"
+	"battleship_client/xdg"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const fileExt = ".txt"
+
+var (
+	ErrNotFound    = errors.New("layout not found")
+	ErrInvalidName = errors.New("layout name must have 1 to 20 letters, digits, spaces, '-' or '_'")
+)
+
+var namePattern = regexp.MustCompile(`^[\p{L}\d _-]{1,20}$`)
+
+// Layouts saved as separate files in the text format, named after the layout.
+type Store struct {
+	dir string
+}
+
+func NewStore(dir string) Store {
+	return Store{dir: dir}
+}
+
+// Returns the default directory with the saved layouts.
+func DefaultDir() (string, error) {
+	dir, err := xdg.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "layouts"), nil
+}
+
+// Returns the names of all saved layouts in alphabetical order.
+func (s Store) Names() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read layouts directory: %w", err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() && filepath.Ext(entry.Name()) == fileExt {
+			names = append(names, strings.TrimSuffix(entry.Name(), fileExt))
+		}
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// Saves the fleet under the name, replacing the layout saved under it before. Only valid fleets can be saved.
+func (s Store) Save(name string, coords []board.Coord) error {
+	path, err := s.path(name)
+	if err != nil {
+		return err
+	}
+	return Export(path, coords)
+}
+
+// Loads the fleet saved under the name. Fails if the saved fleet is not valid, e.g. after the file was edited by hand.
+func (s Store) Load(name string) ([]board.Coord, error) {
+	path, err := s.path(name)
+	if err != nil {
+		return nil, err
+	}
+	coords, err := Import(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("layout %s: %w", name, err)
+	}
+	return coords, nil
+}
+
+// Writes the fleet to the file in the text format, e.g. to share it, creating the directory of the file if needed.
+// Only valid fleets can be written. See `xdg.WriteFile`.
+func Export(path string, coords []board.Coord) error {
+	if err := board.ValidateFleet(coords); err != nil {
+		return fmt.Errorf("invalid layout: %w", err)
+	}
+	if err := xdg.WriteFile(path, []byte(Format(coords)), 0o644); err != nil {
+		return fmt.Errorf("failed to save layout: %w", err)
+	}
+	return nil
+}
+
+// Reads the fleet from the file in the text format. Fails if the fleet is not valid.
+func Import(path string) ([]board.Coord, error) {
+	text, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read layout: %w", err)
+	}
+	coords, err := Parse(string(text))
+	if err == nil {
+		err = board.ValidateFleet(coords)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid layout: %w", err)
+	}
+	return coords, nil
+}
+
+func (s Store) Delete(name string) error {
+	path, err := s.path(name)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(path)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	return err
+}
+
+func (s Store) path(name string) (string, error) {
+	if !namePattern.MatchString(name) {
+		return "", ErrInvalidName
+	}
+	return filepath.Join(s.dir, name+fileExt), nil
+}
@@ -0,0 +1,100 @@
+package layout
+
+import (
+	"battleship_client/board"
+	"errors"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "layouts")
+	store := NewStore(dir)
+	if names, err := store.Names(); err != nil || names != nil {
+		t.Fatalf("Names() of a missing directory = %v, %v, want none", names, err)
+	}
+
+	fleet := board.Cells(board.RandomFleet(rand.New(rand.NewSource(1))))
+	for _, name := range []string{"corner", "Ściana 2", "edge_1"} {
+		if err := store.Save(name, fleet); err != nil {
+			t.Fatalf("Save(%q) error: %s", name, err)
+		}
+	}
+	if names, err := store.Names(); err != nil || !slices.Equal(names, []string{"corner", "edge_1", "Ściana 2"}) {
+		t.Errorf("Names() = %v, %v", names, err)
+	}
+	got, err := store.Load("corner")
+	if err != nil {
+		t.Fatalf("Load() error: %s", err)
+	}
+	if !slices.Equal(sorted(got), sorted(fleet)) {
+		t.Errorf("Load() = %v, want %v", got, fleet)
+	}
+	// No temporary file of the write is left behind.
+	if entries, _ := os.ReadDir(dir); len(entries) != 3 {
+		t.Errorf("layouts directory has %d files, want 3", len(entries))
+	}
+
+	if err := store.Delete("corner"); err != nil {
+		t.Fatalf("Delete() error: %s", err)
+	}
+	if _, err := store.Load("corner"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Load() of the deleted layout error = %v, want %v", err, ErrNotFound)
+	}
+	if err := store.Delete("corner"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Delete() of the deleted layout error = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestStoreErrors(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir)
+	fleet := board.Cells(board.RandomFleet(rand.New(rand.NewSource(1))))
+	tests := []struct {
+		name    string
+		save    func() error
+		wantErr error
+	}{
+		{name: "empty name", save: func() error { return store.Save("", fleet) }, wantErr: ErrInvalidName},
+		{name: "path in the name", save: func() error { return store.Save("../up", fleet) }, wantErr: ErrInvalidName},
+		{name: "long name", save: func() error { return store.Save("abcdefghijklmnopqrstu", fleet) }, wantErr: ErrInvalidName},
+		{name: "incomplete fleet", save: func() error { return store.Save("short", fleet[1:]) }, wantErr: board.ErrFleetSize},
+		{
+			name: "file edited by hand",
+			save: func() error {
+				os.WriteFile(filepath.Join(dir, "edited.txt"), []byte(Format(fleet[1:])), 0o644)
+				_, err := store.Load("edited")
+				return err
+			},
+			wantErr: board.ErrFleetSize,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.save(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExportImport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shared", "corner.txt")
+	fleet := board.Cells(board.RandomFleet(rand.New(rand.NewSource(2))))
+	if err := Export(path, fleet); err != nil {
+		t.Fatalf("Export() error: %s", err)
+	}
+	got, err := Import(path)
+	if err != nil {
+		t.Fatalf("Import() error: %s", err)
+	}
+	if !slices.Equal(sorted(got), sorted(fleet)) {
+		t.Errorf("Import() = %v, want %v", got, fleet)
+	}
+	if _, err := Import(filepath.Join(t.TempDir(), "missing.txt")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Import() of a missing file error = %v, want %v", err, os.ErrNotExist)
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"battleship_client/layout"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Lists, exports and imports the saved placement layouts, so they can be shared in the text format.
+func layouts(args []string) error {
+	dir, err := layout.DefaultDir()
+	if err != nil {
+		return err
+	}
+	fs := flag.NewFlagSet("layouts", flag.ExitOnError)
+	fs.StringVar(&dir, "dir", dir, "directory with the saved layouts")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: layouts [-dir DIR] list | export NAME | import NAME [FILE] | delete NAME")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	store := layout.NewStore(dir)
+
+	args = fs.Args()
+	if len(args) == 0 {
+		fs.Usage()
+		return fmt.Errorf("missing layouts command")
+	}
+	switch {
+	case args[0] == "list" && len(args) == 1:
+		names, err := store.Names()
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			fmt.Println(name)
+		}
+		return nil
+	case args[0] == "export" && len(args) == 2:
+		coords, err := store.Load(args[1])
+		if err != nil {
+			return err
+		}
+		fmt.Print(layout.Format(coords))
+		return nil
+	case args[0] == "import" && (len(args) == 2 || len(args) == 3):
+		// The layout is read from the standard input if no file is given.
+		var in io.Reader = os.Stdin
+		if len(args) == 3 {
+			file, err := os.Open(args[2])
+			if err != nil {
+				return fmt.Errorf("failed to open layout file: %w", err)
+			}
+			defer file.Close()
+			in = file
+		}
+		text, err := io.ReadAll(in)
+		if err != nil {
+			return fmt.Errorf("failed to read layout: %w", err)
+		}
+		coords, err := layout.Parse(string(text))
+		if err != nil {
+			return err
+		}
+		return store.Save(args[1], coords)
+	case args[0] == "delete" && len(args) == 2:
+		return store.Delete(args[1])
+	}
+	fs.Usage()
+	return fmt.Errorf("invalid layouts command: %v", args)
+}
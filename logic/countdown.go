@@ -0,0 +1,72 @@
+package logic
+
+import (
+	"battleship_client/ai"
+	"battleship_client/engine"
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	// Time between the updates of the displayed countdown.
+	countdownTick = time.Millisecond * 250
+	// Time left in the player's turn at which the random shot is fired for them.
+	autoFireBefore = time.Millisecond * 1500
+)
+
+// Counts down the player's turn locally, so the timer does not freeze between the statuses.
+// Every timer received from the server re-syncs the countdown, and a negative one stops it for the opponent's turn.
+// If `autoFire` is not nil, it is called once per turn when the time is about to run out.
+func runCountdown(ctx context.Context, gameUi GameView, timers <-chan int, autoFire func()) {
+	ticker := time.NewTicker(countdownTick)
+	defer ticker.Stop()
+	// Zero during the opponent's turn.
+	var deadline time.Time
+	fired := false
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case timer := <-timers:
+			if timer < 0 {
+				deadline = time.Time{}
+				gameUi.SetTimer("Time: -")
+				continue
+			}
+			// The server rounds the time down to whole seconds, so the countdown is re-synced only if it drifted further,
+			// e.g. at the start of the turn. Otherwise it would jump back up with every status.
+			server := time.Duration(timer) * time.Second
+			if left := time.Until(deadline); deadline.IsZero() || left <= server-time.Second || left >= server+time.Second {
+				deadline = time.Now().Add(server)
+			}
+			// The turn was extended, e.g. after a hit, so the random shot may be needed again.
+			if time.Until(deadline) > autoFireBefore {
+				fired = false
+			}
+		case <-ticker.C:
+			if deadline.IsZero() {
+				continue
+			}
+		}
+		left := max(time.Until(deadline), 0)
+		gameUi.SetCountdown(left)
+		if autoFire != nil && !fired && left <= autoFireBefore {
+			fired = true
+			autoFire()
+		}
+	}
+}
+
+// Fires at a random cell of the opponent's board that was not shot yet, so the turn is not lost to the clock.
+// Runs on its own goroutine, so the target is chosen on a copy of the board taken under the lock of the view.
+func fireRandom(ctx context.Context, gameUi GameView, game *engine.GameSession, strategy ai.Strategy) {
+	view := gameUi.OppGrid()
+	target, err := strategy.Next(view)
+	if err != nil {
+		gameUi.Log(fmt.Sprintf("Auto-fire error: %s", err.Error()))
+		return
+	}
+	gameUi.Log("Auto-fire at %s", target)
+	fireShot(ctx, game, target.String())
+}
@@ -1,158 +1,332 @@
 package logic
 
 import (
+	"battleship_client/ai"
 	"battleship_client/api/client"
+	"battleship_client/board"
+	"battleship_client/engine"
 	"battleship_client/gui/cli"
+	"battleship_client/history"
+	"battleship_client/profile"
+	"battleship_client/session"
+	"battleship_client/shotlog"
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	wGui "github.com/RostKoff/warships-gui/v2"
 )
 
-func StartGame(controller *wGui.GUI, gs client.GameSettings, abandon chan<- rune) error {
+// Time the autoplay waits before each shot.
+const autoplayDelay = time.Millisecond * 500
+
+// Starts the game with the settings and plays it until it ends or is abandoned.
+// After the game ends, its summary is displayed and the player's choice is routed back to the main loop, see `finishGame`.
+func StartGame(controller *wGui.GUI, gs client.GameSettings, opts Options, abandon chan<- rune, rematch chan<- client.GameSettings) error {
 	controller.NewScreen("game")
 	controller.SetScreen("game")
-	gameEnded := false
 
-	apiClient, err := client.InitGame(gs)
+	// Context to cancel additional goroutines and in-flight requests after game is finished.
+	mainEnd, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiClient, err := client.InitGameContext(mainEnd, gs, opts.API)
 	if err != nil {
 		return fmt.Errorf("failed to initialise the game, %w", err)
 	}
-
-	statusRes, err := waitUntilStart(apiClient, controller)
+	// The token is stored first, so the game can be resumed even if the client is closed while waiting.
+	store, err := opts.sessionStore(session.Session{
+		Token:      apiClient.Token,
+		Server:     opts.API.BaseURL,
+		Nick:       gs.Nick,
+		AgainstBot: gs.AgainstBot,
+		StartedAt:  time.Now(),
+	})
 	if err != nil {
-		return fmt.Errorf("fail occured while waiting for start: %w", err)
+		controller.Log("Session error: %s", err)
 	}
-	gameUi, err := displayGame(apiClient, controller, statusRes)
-	if err != nil {
-		return fmt.Errorf("failed to display the game: %w", err)
+	summary, err := playGame(mainEnd, cancel, cli.NewGameUI(controller), apiClient, gs, nil, store, opts, abandon)
+	if summary == nil {
+		return err
 	}
+	cancel()
+	controller.RemoveScreen("game")
+	finishGame(controller, *summary, gs, abandon, rematch)
+	return err
+}
+
+// Continues the game stored by a previous run of the client. The opponent's board is rebuilt from the record of the shots.
+func ResumeGame(controller *wGui.GUI, s session.Session, opts Options, abandon chan<- rune, rematch chan<- client.GameSettings) error {
+	controller.NewScreen("game")
+	controller.SetScreen("game")
 
-	// Context to cancel additional goroutines after game is finished.
 	mainEnd, cancel := context.WithCancel(context.Background())
-	// Goroutine that is responsible for updating GUI according to the game status got from API.
 	defer cancel()
 
+	apiClient := client.NewGameClient(opts.API)
+	apiClient.Token = s.Token
+	store, err := opts.sessionStore(s)
+	if err != nil {
+		controller.Log("Session error: %s", err)
+	}
+	// The description is not stored with the session, so the settings take it from the profile.
+	prof, profErr := loadProfile(opts, client.GameSettings{Nick: s.Nick})
+	if profErr != nil {
+		controller.Log("Profile error: %s", profErr)
+	}
+	gs := client.GameSettings{AgainstBot: s.AgainstBot, Nick: s.Nick, Description: prof.Description}
+	summary, err := playGame(mainEnd, cancel, cli.NewGameUI(controller), apiClient, gs, &s, store, opts, abandon)
+	if summary == nil {
+		return err
+	}
+	cancel()
+	controller.RemoveScreen("game")
+	finishGame(controller, *summary, gs, abandon, rematch)
+	return err
+}
+
+// Plays the game of the client's token until it ends or is abandoned. The context is cancelled with `cancel` when the game is abandoned.
+// The game is started with the settings, or continues the `resumed` session if it is not nil.
+// The shots are stored in the record of the token, and the game in the store if it is not nil, so the game can be resumed until it is over.
+// Returns the summary of the game if it ended, or nil if it was abandoned.
+func playGame(mainEnd context.Context, cancel context.CancelFunc, gameUi GameView, apiClient client.GameClient, gs client.GameSettings, resumed *session.Session, store *session.Store, opts Options, abandon chan<- rune) (*engine.Summary, error) {
+	strategy, err := opts.autoplayStrategy()
+	if err != nil {
+		return nil, err
+	}
+
+	game := engine.NewGameSession(apiClient)
+	gameUi.ShowWaiting()
+	statusRes, err := game.WaitForStart(mainEnd)
+	if err != nil {
+		return nil, fmt.Errorf("fail occured while waiting for start: %w", err)
+	}
+	// A resumed game is measured from the time it was first started.
+	started := time.Now()
+	if resumed != nil && !resumed.StartedAt.IsZero() {
+		started = resumed.StartedAt
+	}
+	if !gs.AgainstBot {
+		err = updateProfile(opts, func(p *profile.Profile) {
+			p.LastOpponent = statusRes.Opponent
+		})
+		if err != nil {
+			gameUi.Log("Profile error: %s", err)
+		}
+	}
+	descs, err := displayGame(mainEnd, apiClient, gameUi, statusRes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to display the game: %w", err)
+	}
+
+	pShips, err := apiClient.BoardContext(mainEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player's ship location: %w", err)
+	}
+
+	recordErr := func(err error) {
+		if err != nil {
+			gameUi.Log("History error: %s", err)
+		}
+	}
+	sessionErr := func(err error) {
+		if err != nil {
+			gameUi.Log("Session error: %s", err)
+		}
+	}
+	// The game is played even if it cannot be recorded.
+	var recorder *history.Recorder
+	if resumed != nil && resumed.HistoryPath != "" {
+		recorder, err = history.OpenRecorder(resumed.HistoryPath)
+		recordErr(err)
+	} else if resumed == nil {
+		recorder, err = opts.historyRecorder()
+		recordErr(err)
+		recordErr(recorder.Start(statusRes.Nick, statusRes.Opponent, pShips, gs.AgainstBot))
+		recordErr(recorder.Descriptions(descs.PlayerDescription, descs.OpponentDescription))
+		sessionErr(store.SetHistoryPath(recorder.Path()))
+	}
+	shots, err := opts.shotRecord(apiClient.Token)
+	if err != nil {
+		sessionErr(err)
+		shots = shotlog.New()
+	}
+	if resumed != nil {
+		hit, miss, _ := shots.Counts()
+		gameUi.RestoreOppBoard(shots.Grid(), hit, miss)
+	}
+
 	// The channel will send messages to the goroutine responsible for displaying errors.
 	errMsgChan := make(chan string)
+	events := game.Subscribe()
+	go game.Run(mainEnd)
 
+	// Closed when the player leaves the game screen.
+	left := make(chan struct{})
 	go func() {
-		btnListen(mainEnd, gameUi, apiClient, abandon)
-		gameEnded = true
+		btnListen(mainEnd, gameUi, game, abandon)
+		close(left)
+		// Stops requests that are still being sent for the abandoned game.
+		cancel()
 	}()
 
 	go errorDisplayer(mainEnd, gameUi, errMsgChan)
 
-	go handleShot(mainEnd, gameUi, apiClient, errMsgChan)
-
-	board, err := apiClient.Board()
-	if err != nil {
-		return fmt.Errorf("failed to get player's ship location: %w", err)
-	}
-	oppShotCount := 0
-	// Main game loop. Gets the game status every second and updates the GUI accordingly
-	for {
-		if gameEnded {
-			break
+	// Statuses of the game in which the player should fire, consumed by the autoplay.
+	turns := make(chan engine.TimerTick, 1)
+	// Timers of the player's turn that re-sync the countdown, negative during the opponent's turn.
+	timers := make(chan int)
+	var autoFire func()
+	if random := opts.autoFireStrategy(); random != nil {
+		autoFire = func() {
+			go fireRandom(mainEnd, gameUi, game, random)
 		}
-		statusRes, err = apiClient.Status()
-		if err != nil {
-			gameUi.Controller.Log(fmt.Sprintf("Status error: %s", err.Error()))
-			errMsgChan <- "Failed to get game status"
-			time.Sleep(time.Second)
-			continue
+	}
+	go runCountdown(mainEnd, gameUi, timers, autoFire)
+	syncTimer := func(timer int) {
+		select {
+		case timers <- timer:
+		case <-mainEnd.Done():
 		}
-		// Updates Player's board to display the opponent's shots, but only if there are new ones.
-		if size := len(statusRes.OpponentShots); oppShotCount != size {
-			err = gameUi.HandleOppShots(board, statusRes.OpponentShots)
+	}
+	if strategy != nil {
+		go handleAutoShot(mainEnd, gameUi, game, strategy, turns, errMsgChan)
+	} else {
+		go handleShot(mainEnd, gameUi, game, errMsgChan)
+	}
+
+	var summary *engine.Summary
+	oppShots := make([]string, 0)
+	// Updates the GUI with the events of the game until it is over.
+	for ev := range events {
+		switch ev := ev.(type) {
+		case engine.TurnChanged:
+			if ev.PlayerTurn {
+				gameUi.SetTurn("Your turn!")
+			} else {
+				gameUi.SetTurn("Opponent Turn")
+				syncTimer(-1)
+			}
+		case engine.TimerTick:
+			syncTimer(ev.Timer)
+			// Only the latest status is kept, the autoplay does not need the older ones.
+			select {
+			case turns <- ev:
+			default:
+			}
+		case engine.OpponentShot:
+			// Updates Player's board to display the opponent's shots.
+			err = gameUi.HandleOppShots(pShips, ev.Shots)
 			if err != nil {
-				gameUi.Controller.Log("Handle opponent shots error: %s", err.Error())
-				return err
+				gameUi.Log("Handle opponent shots error: %s", err.Error())
+				return nil, err
+			}
+			oppShots = ev.Shots
+			recordErr(recorder.OpponentShots(ev.Shots))
+		case engine.ShotResult:
+			recordErr(recorder.Shot(ev.Coord, ev.Result))
+			sessionErr(shots.Add(ev.Coord, ev.Result))
+			err = gameUi.HandlePShot(ev.Result, ev.Coord)
+			if err != nil {
+				sendError(mainEnd, errMsgChan, "Failed to handle player shot")
+				gameUi.Log(fmt.Sprintf("Player shot error: %s", err.Error()))
+				continue
+			}
+			gameUi.CalculateAccuracy()
+		case engine.Error:
+			showError(mainEnd, gameUi, ev, errMsgChan)
+		case engine.Connection:
+			gameUi.SetConnection(ev.Failures, ev.LastPoll)
+		case engine.GameEnded:
+			if ev.Outcome != history.OutcomeAbandoned {
+				summary = gameSummary(ev.Outcome, statusRes, pShips, oppShots, shots, gameUi.OppGrid())
+				summary.Duration = time.Since(started)
+			}
+			// The game cannot be resumed once it is over.
+			sessionErr(store.Clear())
+			sessionErr(shots.Remove())
+			recordErr(recorder.End(ev.Outcome))
+			switch ev.Outcome {
+			case history.OutcomeSessionLost:
+				gameUi.SetEnd("Game session is lost!\n")
+			case history.OutcomeLose:
+				gameUi.SetEnd("You lose!\n")
+			case history.OutcomeWin:
+				gameUi.SetEnd("You won!\n")
 			}
-			oppShotCount = size
-		}
-		if statusRes.Status == "ended" {
-			break
-		}
-		if !statusRes.ShouldFire {
-			gameUi.TurnText.SetText("Opponent Turn")
-			gameUi.Timer.SetText("Time: -")
-		} else {
-			gameUi.Timer.SetText(fmt.Sprintf("Time: %d", statusRes.Timer))
-			gameUi.TurnText.SetText("Your turn!")
 		}
-		time.Sleep(time.Second)
 	}
-	if statusRes.LastGameStatus == "lose" {
-		gameUi.EndText.SetText("You lose!\n")
-	} else {
-		gameUi.EndText.SetText("You won!\n")
+	if summary != nil {
+		return summary, nil
 	}
-	for !gameEnded {
-		time.Sleep(time.Second)
+	<-left
+	return nil, nil
+}
+
+// Returns the statistics of the finished game. The player's shots are counted from their record, so a resumed game is summarised as a whole.
+func gameSummary(outcome string, statusRes client.StatusResponse, pShips []string, oppShots []string, shots *shotlog.Record, oppGrid board.Grid) *engine.Summary {
+	summary := engine.Summary{
+		Outcome:       outcome,
+		Nick:          statusRes.Nick,
+		Opponent:      statusRes.Opponent,
+		OpponentBoard: oppGrid,
 	}
-	return err
+	results := make([]string, 0)
+	for _, shot := range shots.Shots() {
+		results = append(results, shot.Result)
+	}
+	summary.CountShots(results)
+	// The coordinates were already parsed when they were displayed on the board.
+	ships, _ := board.ParseCoords(pShips)
+	oppCoords, _ := board.ParseCoords(oppShots)
+	summary.PlayerBoard = engine.PlayerBoard(ships, oppCoords)
+	return &summary
 }
 
-// Fetches the status from the API every second until the game starts, and refreshes the game session every 10 seconds.
-// Returns the status of the started game.
-func waitUntilStart(apiClient client.GameClient, controller *wGui.GUI) (statusRes client.StatusResponse, err error) {
-	waitTxt := wGui.NewText(1, 1, "Waiting for game to start...", nil)
-	controller.Draw(waitTxt)
-
-	// Requesting the API for the status of the game until it is started.
-	// Refresh count is used to refresh game session every 10 seconds.
-	for refreshCount := 0; ; refreshCount++ {
-		if refreshCount == 10 {
-			err = apiClient.Refresh()
-			if err != nil {
-				return statusRes, fmt.Errorf("failed to refresh game session: %w", err)
-			}
-			refreshCount = 0
-		}
-		statusRes, err = apiClient.Status()
-		if err != nil {
-			return statusRes, fmt.Errorf("failed to get game status: %w", err)
-		}
-		if statusRes.Status != "game_in_progress" {
-			time.Sleep(time.Second)
-			continue
-		}
-		break
+// Logs the failed request and displays its message to the player.
+func showError(ctx context.Context, gameUi GameView, ev engine.Error, errChan chan<- string) {
+	fallback, kind := "Failed to fire!", "Fire"
+	if ev.Op == engine.OpStatus {
+		fallback, kind = "Failed to get game status", "Status"
+	}
+	gameUi.Log(fmt.Sprintf("%s error: %s", kind, ev.Err.Error()))
+	sendError(ctx, errChan, errorMessage(ev.Err, fallback))
+}
+
+// Sends the message to the goroutine that displays it, unless the game is over and the message would never be received.
+func sendError(ctx context.Context, errChan chan<- string, msg string) {
+	select {
+	case errChan <- msg:
+	case <-ctx.Done():
 	}
-	controller.Remove(waitTxt)
-	return statusRes, nil
 }
 
-func displayGame(apiClient client.GameClient, controller *wGui.GUI, statusRes client.StatusResponse) (gameUi *cli.GameUI, err error) {
-	board, err := apiClient.Board()
+// Draws the game screen with the player's ships, nicks and descriptions. Returns the descriptions that were drawn.
+func displayGame(ctx context.Context, apiClient client.GameClient, gameUi GameView, statusRes client.StatusResponse) (descs client.DescriptionResponse, err error) {
+	pShips, err := apiClient.BoardContext(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get player's ship location: %w", err)
+		return descs, fmt.Errorf("failed to get player's ship location: %w", err)
 	}
-	gameUi = cli.InitGameUI(controller)
+	gameUi.Show()
 	// Fill the board with ships
-	for _, coord := range board {
-		gameUi.PBoard.UpdateState(coord, wGui.Ship)
+	if err = gameUi.DrawShips(pShips); err != nil {
+		return descs, fmt.Errorf("failed to draw player's ships: %w", err)
 	}
 	gameUi.DrawNicks(statusRes.Nick, statusRes.Opponent)
 
-	var pDesc, oppDesc string
-	descs, err := apiClient.PlayerDescriptions()
+	descs, err = apiClient.PlayerDescriptionsContext(ctx)
 	if err != nil {
-		gameUi.Controller.Log("Player Descriptions Error: %s", err)
-		pDesc = "n/a"
-		oppDesc = "n/a"
-	} else {
-		pDesc = descs.PlayerDescription
-		oppDesc = descs.OpponentDescription
+		gameUi.Log("Player Descriptions Error: %s", err)
+		descs.PlayerDescription = "n/a"
+		descs.OpponentDescription = "n/a"
 	}
-	gameUi.DrawDescriptions(pDesc, oppDesc)
-	return
+	gameUi.DrawDescriptions(descs.PlayerDescription, descs.OpponentDescription)
+	return descs, nil
 }
 
 // Responsible for logic related to the shot. The context is used to end the function when the game is over.
-func handleShot(ctx context.Context, gameUi *cli.GameUI, client client.GameClient, errChan chan<- string) {
+func handleShot(ctx context.Context, gameUi GameView, game *engine.GameSession, errChan chan<- string) {
 	for {
 		select {
 		case <-ctx.Done():
@@ -160,28 +334,76 @@ func handleShot(ctx context.Context, gameUi *cli.GameUI, client client.GameClien
 		default:
 			coord, err := gameUi.ListenForShot(ctx)
 			if err != nil {
-				errChan <- "Failed to handle click!"
-				gameUi.Controller.Log(fmt.Sprintf("Listen Error: %s", err.Error()))
+				sendError(ctx, errChan, "Failed to handle click!")
+				gameUi.Log(fmt.Sprintf("Listen Error: %s", err.Error()))
 			}
-			fireRes, err := client.Fire(coord)
-			if err != nil {
-				errChan <- "Failed to fire!"
-				gameUi.Controller.Log(fmt.Sprintf("Fire error: %s", err.Error()))
+			// Listening stops with an empty coordinate when the game is over.
+			if coord == "" {
 				continue
 			}
-			err = gameUi.HandlePShot(fireRes, coord)
+			if !fireShot(ctx, game, coord) {
+				return
+			}
+		}
+	}
+}
+
+// Fires the shots chosen by the strategy instead of the player.
+// Waits for a status in which the player should fire, and fires before the turn timer runs out.
+func handleAutoShot(ctx context.Context, gameUi GameView, game *engine.GameSession, strategy ai.Strategy, turns <-chan engine.TimerTick, errChan chan<- string) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case tick := <-turns:
+			// Waits a moment so the player can follow the game, unless the time is running out.
+			if tick.Timer > 1 {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(autoplayDelay):
+				}
+			}
+			target, err := strategy.Next(gameUi.OppGrid())
 			if err != nil {
-				errChan <- "Failed to handle player shot"
-				gameUi.Controller.Log(fmt.Sprintf("Player shot error: %s", err.Error()))
+				sendError(ctx, errChan, "Autoplay failed to choose a target")
+				gameUi.Log(fmt.Sprintf("Autoplay error: %s", err.Error()))
 				continue
 			}
-			gameUi.CalculateAccuracy()
+			if !fireShot(ctx, game, target.String()) {
+				return
+			}
+			// The statuses received before the shot may be outdated, e.g. the turn may have passed after a miss.
+			select {
+			case <-turns:
+			default:
+			}
 		}
 	}
 }
 
+// Fires at the coordinate. The result or the error of the shot is delivered as an event of the game.
+// Returns false if the game session is lost and no more shots can be fired.
+func fireShot(ctx context.Context, game *engine.GameSession, coord string) bool {
+	err := game.Fire(ctx, coord)
+	if err == nil {
+		return true
+	}
+	if ctx.Err() != nil || engine.IsSessionLost(err) {
+		return false
+	}
+	if errors.Is(err, client.ErrRateLimited) {
+		select {
+		case <-time.After(engine.RetryDelay(err)):
+		case <-ctx.Done():
+			return false
+		}
+	}
+	return true
+}
+
 // Displays an error message received from the `errChan` for 3 seconds and then hides it.
-func errorDisplayer(ctx context.Context, gameUi *cli.GameUI, errChan <-chan string) {
+func errorDisplayer(ctx context.Context, gameUi GameView, errChan <-chan string) {
 	// Initilise the timer.
 	errTimer := time.NewTimer(time.Second * 10)
 	errTimer.Stop()
@@ -191,17 +413,17 @@ func errorDisplayer(ctx context.Context, gameUi *cli.GameUI, errChan <-chan stri
 			return
 		case errMsg := <-errChan:
 			// Displays new message and resets the timer.
-			gameUi.ErrorText.SetText(errMsg)
+			gameUi.ShowError(errMsg)
 			errTimer.Stop()
 			errTimer.Reset(time.Second * 3)
 		case <-errTimer.C:
 			// Hides the message when the timer expires.
-			gameUi.ErrorText.SetText("")
+			gameUi.ShowError("")
 		}
 	}
 }
 
-func btnListen(ctx context.Context, gameUi *cli.GameUI, client client.GameClient, abandon chan<- rune) {
+func btnListen(ctx context.Context, gameUi GameView, game *engine.GameSession, abandon chan<- rune) {
 	for {
 		select {
 		case <-ctx.Done():
@@ -209,11 +431,29 @@ func btnListen(ctx context.Context, gameUi *cli.GameUI, client client.GameClient
 		default:
 			opt := gameUi.BtnListen(ctx)
 			switch opt {
+			case cli.HintOpt:
+				gameUi.ToggleHint()
 			case cli.AbandonOpt:
-				client.Abandon()
+				game.Abandon()
 				abandon <- ' '
 				return
 			}
 		}
 	}
 }
+
+// Returns the message displayed to the player for the error returned by the API client.
+// The fallback is used for errors that do not have a dedicated message.
+func errorMessage(err error, fallback string) string {
+	switch {
+	case errors.Is(err, client.ErrNotYourTurn):
+		return "Wait for your turn!"
+	case errors.Is(err, client.ErrInvalidCoord):
+		return "Invalid coordinate!"
+	case errors.Is(err, client.ErrRateLimited):
+		return "Too many requests, slow down!"
+	case engine.IsSessionLost(err):
+		return "Game session is lost!"
+	}
+	return fallback
+}
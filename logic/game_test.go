@@ -0,0 +1,209 @@
+package logic
+
+import (
+	"battleship_client/api/client"
+	"battleship_client/api/server"
+	"battleship_client/board"
+	"battleship_client/engine"
+	"battleship_client/gui/cli"
+	"battleship_client/gui/fake"
+	"battleship_client/history"
+	"battleship_client/profile"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// Starts the stand-in server and returns the options of the clients that connect to it.
+func startServer(t *testing.T) client.Options {
+	t.Helper()
+	opts := server.DefaultOptions()
+	opts.BotDelay = 0
+	opts.Seed = 1
+	srv := httptest.NewServer(server.New(opts))
+	t.Cleanup(srv.Close)
+	return client.Options{BaseURL: srv.URL + "/api"}
+}
+
+// Result of `playGame` run on its own goroutine.
+type gameResult struct {
+	summary *engine.Summary
+	err     error
+}
+
+// Starts the game against the bot and plays it on the fake view in the background.
+func startGame(t *testing.T, view *fake.GameView, opts Options) (<-chan gameResult, <-chan rune) {
+	t.Helper()
+	gs := client.GameSettings{Nick: "alice", AgainstBot: true}
+	apiClient, err := client.InitGame(gs, opts.API)
+	if err != nil {
+		t.Fatalf("InitGame() error: %s", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	abandon := make(chan rune, 1)
+	done := make(chan gameResult, 1)
+	go func() {
+		summary, err := playGame(ctx, cancel, view, apiClient, gs, nil, nil, opts, abandon)
+		done <- gameResult{summary, err}
+	}()
+	return done, abandon
+}
+
+// Plays the whole game on the fake view, and checks the summary and the displayed state it ends with.
+// The shots and the hint toggles are sent while the game events update the boards, so `go test -race` checks
+// that the views are only accessed in a synchronized way.
+// The player fires at the first cell that is still empty, and the stand-in server places the fleets and fires the bot's shots
+// from its seed, so the whole game is the same in every run.
+func TestPlayGame(t *testing.T) {
+	view := fake.NewGameView()
+	done, _ := startGame(t, view, Options{API: startServer(t)})
+
+	var res gameResult
+	for shot := 0; res.summary == nil && res.err == nil; shot++ {
+		grid := view.OppGrid()
+		empty := grid.Find(board.Empty)
+		if len(empty) == 0 {
+			t.Fatalf("no cell left to fire at before the game ended")
+		}
+		target := empty[0]
+		select {
+		case view.Shots <- target.String():
+		case res = <-done:
+			continue
+		case <-time.After(time.Second * 10):
+			t.Fatalf("game did not take a shot in time")
+		}
+		if shot%10 == 0 {
+			select {
+			case view.Buttons <- cli.HintOpt:
+			case res = <-done:
+				continue
+			}
+		}
+		// The next target is chosen once the result of the shot is displayed.
+		for grid := view.OppGrid(); grid.At(target) == board.Empty && res.summary == nil && res.err == nil; grid = view.OppGrid() {
+			select {
+			case res = <-done:
+			case <-time.After(time.Millisecond * 5):
+			}
+		}
+	}
+	if res.err != nil {
+		t.Fatalf("playGame() error: %s", res.err)
+	}
+
+	summary := res.summary
+	want := engine.Summary{Outcome: history.OutcomeLose, Nick: "alice", Shots: 44, Hits: 12}
+	if summary.Outcome != want.Outcome || summary.Shots != want.Shots || summary.Hits != want.Hits {
+		t.Errorf("summary = %s after %d shots with %d hits, want %s after %d shots with %d hits",
+			summary.Outcome, summary.Shots, summary.Hits, want.Outcome, want.Shots, want.Hits)
+	}
+	if summary.Nick != want.Nick || summary.Opponent == "" {
+		t.Errorf("players = %q against %q, want alice against the bot", summary.Nick, summary.Opponent)
+	}
+	// Only the shots that did not sink a ship are counted as hits on the board.
+	sunk := len(summary.OpponentBoard.SunkShips())
+	if hit, miss, _ := view.Accuracy(); hit != summary.Hits-sunk || miss != summary.Shots-summary.Hits {
+		t.Errorf("board counts %d hits and %d misses, want %d and %d", hit, miss, summary.Hits-sunk, summary.Shots-summary.Hits)
+	}
+	if summary.OpponentBoard != view.OppGrid() {
+		t.Errorf("summary board of the opponent differs from the displayed one")
+	}
+	if view.End() == "" {
+		t.Errorf("end of the game is not displayed")
+	}
+	if pNick, _ := view.Nicks(); pNick != "alice" {
+		t.Errorf("displayed nick = %q, want alice", pNick)
+	}
+}
+
+// Plays the game against the bot without a stored session, so the mode of the game can only come from its settings.
+func TestPlayGameWithoutSession(t *testing.T) {
+	dir := t.TempDir()
+	opts := Options{
+		API:         startServer(t),
+		HistoryDir:  filepath.Join(dir, "history"),
+		ProfilePath: filepath.Join(dir, "profile.json"),
+	}
+	view := fake.NewGameView()
+	done, _ := startGame(t, view, opts)
+	// The buttons are listened for once the game is set up.
+	view.Buttons <- cli.AbandonOpt
+	if res := <-done; res.err != nil {
+		t.Fatalf("playGame() error: %s", res.err)
+	}
+
+	games, _, err := history.List(opts.HistoryDir)
+	if err != nil || len(games) != 1 {
+		t.Fatalf("history.List() = %d games, %v, want 1 game", len(games), err)
+	}
+	if !games[0].AgainstBot {
+		t.Errorf("recorded game is not against the bot")
+	}
+	prof, err := profile.Load(opts.ProfilePath)
+	if err != nil {
+		t.Fatalf("profile.Load() error: %s", err)
+	}
+	if prof.LastOpponent != "" {
+		t.Errorf("last opponent = %q, want none after a game against the bot", prof.LastOpponent)
+	}
+}
+
+// Lets the turns run out without the player's shots, so the random shots are fired for the player, and then abandons the game.
+func TestAutoFire(t *testing.T) {
+	serverOpts := server.DefaultOptions()
+	// The random shot is fired 1.5s before the end of the turn, and the server rounds the timer down to whole seconds.
+	serverOpts.TurnTime = time.Second * 4
+	serverOpts.BotDelay = 0
+	serverOpts.Seed = 1
+	srv := httptest.NewServer(server.New(serverOpts))
+	t.Cleanup(srv.Close)
+
+	view := fake.NewGameView()
+	done, abandon := startGame(t, view, Options{API: client.Options{BaseURL: srv.URL + "/api"}, AutoFire: true})
+
+	deadline := time.Now().Add(time.Second * 20)
+	for {
+		if hit, miss, _ := view.Accuracy(); hit+miss >= 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("random shots were not fired in time, logs: %v", view.Logs())
+		}
+		time.Sleep(time.Millisecond * 50)
+	}
+	view.Buttons <- cli.AbandonOpt
+
+	res := <-done
+	if res.err != nil || res.summary != nil {
+		t.Errorf("playGame() = %v, %v, want no summary of the abandoned game", res.summary, res.err)
+	}
+	if r := <-abandon; r != ' ' {
+		t.Errorf("abandon = %q, want ' '", r)
+	}
+}
+
+// Cancels the game while the shot waits out the delay requested by the rate limited server.
+func TestFireShotStopsWhenCancelled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	t.Cleanup(srv.Close)
+	game := engine.NewGameSession(client.NewGameClient(client.Options{BaseURL: srv.URL, RetryMax: 0}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	time.AfterFunc(time.Millisecond*100, cancel)
+	start := time.Now()
+	if fireShot(ctx, game, "A1") {
+		t.Errorf("fireShot() = true, want false after the game was cancelled")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second*5 {
+		t.Errorf("fireShot() returned after %s, want right after the game was cancelled", elapsed)
+	}
+}
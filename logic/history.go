@@ -0,0 +1,136 @@
+package logic
+
+import (
+	"battleship_client/gui/cli"
+	"battleship_client/history"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	wGui "github.com/RostKoff/warships-gui/v2"
+)
+
+// Time between the moves when the replay is playing.
+const replayInterval = time.Second
+
+// Displays the games recorded in the directory on a separate screen and returns when the player goes back.
+// Clicking a game opens its replay.
+func DisplayHistory(controller *wGui.GUI, dir string) {
+	controller.NewScreen("history")
+	controller.SetScreen("history")
+	defer controller.RemoveScreen("history")
+
+	ui := cli.InitHistory(controller)
+	games, skipped, err := history.List(dir)
+	for _, skipErr := range skipped {
+		controller.Log("Skipped a recorded game: %s", skipErr)
+	}
+	if err != nil {
+		controller.Log(fmt.Sprintf("failed to load game history: %s", err.Error()))
+		ui.ShowInfo("Failed to load the game history")
+	} else {
+		ui.DrawGames(games)
+	}
+
+	ctx := context.Background()
+	for {
+		switch key := ui.Listen(ctx); key {
+		case cli.BackOpt:
+			return
+		case cli.OlderOpt:
+			ui.OlderPage()
+		case cli.NewerOpt:
+			ui.NewerPage()
+		default:
+			for _, game := range games {
+				if game.ID == key {
+					DisplayReplay(controller, game)
+					controller.SetScreen("history")
+					break
+				}
+			}
+		}
+	}
+}
+
+// Replays the recorded game on a separate screen and returns when the player goes back.
+func DisplayReplay(controller *wGui.GUI, game history.Game) {
+	controller.NewScreen("replay")
+	controller.SetScreen("replay")
+	defer controller.RemoveScreen("replay")
+
+	ui := cli.InitReplay(controller, game)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Clicks are received in a separate goroutine, so the replay can move on while waiting for them.
+	clicks := make(chan string)
+	go func() {
+		for {
+			opt := ui.Listen(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case clicks <- opt:
+			}
+		}
+	}()
+
+	move := 0
+	var ticker *time.Ticker
+	// Nil while the replay is paused, so the case never fires.
+	var tick <-chan time.Time
+	pause := func() {
+		if ticker != nil {
+			ticker.Stop()
+			ticker, tick = nil, nil
+		}
+		ui.SetPlaying(false)
+	}
+	defer pause()
+
+	for {
+		select {
+		case <-tick:
+			move++
+			if move >= ui.Moves() {
+				pause()
+			}
+		case opt := <-clicks:
+			ui.ShowError("")
+			switch opt {
+			case cli.BackOpt:
+				return
+			case cli.FirstMoveOpt:
+				move = 0
+			case cli.PrevMoveOpt:
+				move = max(move-1, 0)
+			case cli.NextMoveOpt:
+				move = min(move+1, ui.Moves())
+			case cli.LastMoveOpt:
+				move = ui.Moves()
+			case cli.PlayOpt:
+				if ticker != nil {
+					pause()
+					break
+				}
+				// Playing a finished replay starts it over.
+				if move >= ui.Moves() {
+					move = 0
+				}
+				ticker = time.NewTicker(replayInterval)
+				tick = ticker.C
+				ui.SetPlaying(true)
+			case cli.JumpOpt:
+				n, err := strconv.Atoi(ui.JumpTarget())
+				if err != nil || n < 0 || n > ui.Moves() {
+					ui.ShowError(fmt.Sprintf("Enter a move from 0 to %d", ui.Moves()))
+					break
+				}
+				move = n
+			}
+		}
+		ui.ShowMove(move)
+	}
+}
@@ -0,0 +1,93 @@
+package logic
+
+import (
+	"battleship_client/api/client"
+	"battleship_client/gui/cli"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	wGui "github.com/RostKoff/warships-gui/v2"
+)
+
+// Displays the leaderboard on a separate screen and returns when the player goes back.
+// The row of the player with the given nick is highlighted.
+func DisplayLeaderboard(controller *wGui.GUI, opts client.Options, nick string) {
+	controller.NewScreen("leaderboard")
+	controller.SetScreen("leaderboard")
+	defer controller.RemoveScreen("leaderboard")
+
+	ui := cli.InitLeaderboard(controller)
+	apiClient := client.NewGameClient(opts)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Only one load runs at a time, so the rows of an outdated load are never drawn over the newer ones.
+	var loading sync.WaitGroup
+	loadCancel := context.CancelFunc(func() {})
+	load := func() {
+		loadCancel()
+		loading.Wait()
+		var loadCtx context.Context
+		loadCtx, loadCancel = context.WithCancel(ctx)
+		loading.Add(1)
+		go func() {
+			defer loading.Done()
+			loadStats(loadCtx, ui, apiClient, nick)
+		}()
+	}
+	defer func() {
+		loadCancel()
+		loading.Wait()
+	}()
+
+	load()
+	for {
+		switch ui.Listen(ctx) {
+		case cli.RefreshStatsOpt:
+			ui.ShowInfo("Loading...")
+			load()
+		case cli.BackOpt:
+			return
+		}
+	}
+}
+
+// Fetches the ranking and the statistics of the player, and displays them on the leaderboard.
+func loadStats(ctx context.Context, ui *cli.LeaderboardUI, apiClient client.GameClient, nick string) {
+	stats, err := apiClient.StatsContext(ctx)
+	if ctx.Err() != nil {
+		return
+	}
+	if err != nil {
+		ui.Controller.Log(fmt.Sprintf("failed to get stats: %s", err.Error()))
+		ui.ShowInfo(statsErrorMessage(err))
+		return
+	}
+	var own *client.PlayerStatsResponse
+	if nick != "" {
+		st, err := apiClient.PlayerStatsContext(ctx, nick)
+		if err == nil {
+			own = &st
+		} else if !errors.Is(err, client.ErrPlayerNotFound) {
+			ui.Controller.Log(fmt.Sprintf("failed to get player stats: %s", err.Error()))
+		}
+	}
+	if ctx.Err() != nil {
+		return
+	}
+	ui.DrawStats(stats, nick, own)
+}
+
+// Returns the message displayed on the leaderboard for the error returned by the API client.
+// The statistics do not belong to a game, so the errors of the game session do not apply to them.
+func statsErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, client.ErrNotFound):
+		return "The leaderboard is not available on this server"
+	case errors.Is(err, client.ErrRateLimited):
+		return "Too many requests, slow down!"
+	}
+	return "Failed to get the leaderboard"
+}
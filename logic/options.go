@@ -0,0 +1,74 @@
+package logic
+
+import (
+	"battleship_client/ai"
+	"battleship_client/api/client"
+	"battleship_client/history"
+	"battleship_client/session"
+	"battleship_client/shotlog"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Options of the game flow that are not sent to the server.
+type Options struct {
+	// Connection to the game server.
+	API client.Options
+	// Name of the strategy that fires the shots instead of the player. Empty means the player fires manually.
+	Autoplay string
+	// Fires at a random cell when the player's turn is about to run out. Used only when the player fires manually.
+	AutoFire bool
+	// Directory where the games are recorded. Empty means the games are not recorded.
+	HistoryDir string
+	// Directory where the placement layouts are saved.
+	LayoutDir string
+	// File with the player's profile. Empty means the profile is not stored.
+	ProfilePath string
+	// File where the game in progress is stored, so it can be resumed. Empty means games cannot be resumed.
+	SessionPath string
+	// Directory where the records of the player's shots are stored by the game token. Empty means they are kept in memory.
+	ShotDir string
+}
+
+// Creates the strategy selected for autoplay, or returns nil if the player fires manually.
+func (o Options) autoplayStrategy() (ai.Strategy, error) {
+	if o.Autoplay == "" {
+		return nil, nil
+	}
+	strategy, err := ai.NewStrategy(o.Autoplay, rand.New(rand.NewSource(time.Now().UnixNano())))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create autoplay strategy: %w", err)
+	}
+	return strategy, nil
+}
+
+// Returns the strategy that fires for the player when their turn is about to run out, or nil if it is disabled.
+func (o Options) autoFireStrategy() ai.Strategy {
+	if !o.AutoFire || o.Autoplay != "" {
+		return nil
+	}
+	strategy, _ := ai.NewStrategy(ai.RandomStrategy, rand.New(rand.NewSource(time.Now().UnixNano())))
+	return strategy
+}
+
+// Creates the recorder for a new game, or returns nil if the games are not recorded.
+func (o Options) historyRecorder() (*history.Recorder, error) {
+	if o.HistoryDir == "" {
+		return nil, nil
+	}
+	return history.NewRecorder(o.HistoryDir)
+}
+
+// Stores the session in the file given in the options, or returns nil if games cannot be resumed.
+func (o Options) sessionStore(s session.Session) (*session.Store, error) {
+	if o.SessionPath == "" {
+		return nil, nil
+	}
+	return session.Create(o.SessionPath, s)
+}
+
+// Opens the record of the shots of the game with the token from the directory given in the options.
+func (o Options) shotRecord(token string) (*shotlog.Record, error) {
+	return shotlog.Open(o.ShotDir, token)
+}
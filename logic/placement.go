@@ -1,41 +1,157 @@
 package logic
 
 import (
+	"battleship_client/board"
 	"battleship_client/gui/cli"
+	"battleship_client/layout"
 	"context"
+	"fmt"
+	"math/rand"
+	"path/filepath"
+	"time"
 
 	wGui "github.com/RostKoff/warships-gui/v2"
 )
 
-func DisplayPlacement(controller *wGui.GUI, placement chan<- []string, abort chan<- rune) {
+// Displays the placement screen and sends the placed fleet to the channel.
+// The layouts are saved to and loaded from the directory given in the options.
+func DisplayPlacement(controller *wGui.GUI, placement chan<- []string, abort chan<- rune, opts Options) {
 	controller.NewScreen("placement")
 	controller.SetScreen("placement")
 	defer controller.RemoveScreen("placement")
 	ui := cli.InitPlacement(controller)
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	runPlacement(ui, placement, abort, layout.NewStore(opts.LayoutDir), rng)
+}
+
+// Handles the clicks on the placement view until the fleet is placed or the player goes back.
+func runPlacement(ui PlacementView, placement chan<- []string, abort chan<- rune, store layout.Store, rng *rand.Rand) {
 	ctx, mainEnd := context.WithCancel(context.Background())
 	defer mainEnd()
 	go handlePlacementClick(ui, ctx)
-	opt := ui.SetBtnListen(ctx)
-	switch opt {
-	case cli.PlacementOpt:
-		coords := ui.ShipCoords()
-		if len(coords) == 20 {
-			placement <- ui.ShipCoords()
-		} else {
-			placement <- []string{}
+	for {
+		opt := ui.SetBtnListen(ctx)
+		switch opt {
+		case cli.RandomizeOpt:
+			ui.Randomize(rng)
+			continue
+		case cli.SaveLayoutOpt:
+			saveLayout(ui, store)
+			if ui.LayoutsShown() {
+				showLayouts(ui, store)
+			}
+			continue
+		case cli.LoadLayoutOpt:
+			if ui.LayoutsShown() {
+				ui.HideLayouts()
+			} else {
+				showLayouts(ui, store)
+			}
+			continue
+		case cli.ExportLayoutOpt:
+			exportLayout(ui)
+			continue
+		case cli.ImportLayoutOpt:
+			importLayout(ui)
+			continue
+		case cli.PlacementOpt:
+			coords := ui.ShipCoords()
+			// If no ship was placed, a random fleet is displayed, and it is sent only when the player clicks the button again.
+			// An incomplete fleet is reported to the player.
+			if len(coords) == 0 {
+				ui.Randomize(rng)
+				ui.ShowInfo("Random fleet placed, click \"Set configuration\" to play with it")
+				continue
+			}
+			if err := board.ValidateCoords(coords); err != nil {
+				ui.ShowError(fmt.Sprintf("Invalid fleet: %s", err))
+				continue
+			}
+			placement <- coords
+		case cli.GoBack:
+			abort <- ' '
+		default:
+			if name, ok := cli.LayoutKey(opt); ok {
+				loadLayout(ui, store, name)
+			}
+			continue
 		}
-	case cli.GoBack:
-		abort <- ' '
+		return
 	}
 }
 
-func handlePlacementClick(ui *cli.PlacementUI, ctx context.Context) {
-	select {
-	case <-ctx.Done():
+// Saves the placed fleet under the name typed by the player.
+func saveLayout(ui PlacementView, store layout.Store) {
+	name := ui.LayoutName()
+	coords, err := board.ParseCoords(ui.ShipCoords())
+	if err == nil {
+		err = store.Save(name, coords)
+	}
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to save layout: %s", err))
 		return
-	default:
-		for {
-			ui.Listen(ctx)
-		}
+	}
+	ui.ShowInfo(fmt.Sprintf("Layout %s saved", name))
+}
+
+// Replaces the placed ships with the layout saved under the name.
+func loadLayout(ui PlacementView, store layout.Store, name string) {
+	coords, err := store.Load(name)
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to load layout: %s", err))
+		return
+	}
+	ui.SetShips(board.Ships(coords))
+	ui.ShowInfo(fmt.Sprintf("Layout %s loaded", name))
+}
+
+// Writes the placed fleet in the text format to the file typed in place of the layout name, so it can be shared.
+func exportLayout(ui PlacementView) {
+	path := layoutFile(ui.LayoutName())
+	coords, err := board.ParseCoords(ui.ShipCoords())
+	if err == nil {
+		err = layout.Export(path, coords)
+	}
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to export layout: %s", err))
+		return
+	}
+	ui.ShowInfo(fmt.Sprintf("Layout exported to %s", path))
+}
+
+// Replaces the placed ships with the fleet read from the file typed in place of the layout name.
+func importLayout(ui PlacementView) {
+	path := layoutFile(ui.LayoutName())
+	coords, err := layout.Import(path)
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to import layout: %s", err))
+		return
+	}
+	ui.SetShips(board.Ships(coords))
+	ui.ShowInfo(fmt.Sprintf("Layout imported from %s", path))
+}
+
+// Returns the file of the shared layout typed by the player, relative to the working directory.
+// The extension of the saved layouts is added if none is typed, e.g. "corner" is the file "corner.txt".
+func layoutFile(name string) string {
+	if filepath.Ext(name) == "" {
+		return name + ".txt"
+	}
+	return name
+}
+
+func showLayouts(ui PlacementView, store layout.Store) {
+	names, err := store.Names()
+	if err != nil {
+		ui.ShowError(fmt.Sprintf("Failed to list layouts: %s", err))
+		return
+	}
+	ui.ShowLayouts(names)
+}
+
+func handlePlacementClick(ui PlacementView, ctx context.Context) {
+	// Listening returns right away once the context is done, so it has to be checked before every click.
+	for ctx.Err() == nil {
+		ui.Listen(ctx)
 	}
 }
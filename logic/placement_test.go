@@ -0,0 +1,171 @@
+package logic
+
+import (
+	"battleship_client/board"
+	"battleship_client/gui/cli"
+	"battleship_client/gui/fake"
+	"battleship_client/layout"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Result of `runPlacement` run on its own goroutine.
+type placementResult struct {
+	coords []string
+	abort  rune
+}
+
+// Runs the placement on the fake view in the background.
+func startPlacement(t *testing.T, view *fake.PlacementView, store layout.Store) <-chan placementResult {
+	t.Helper()
+	placement := make(chan []string)
+	abort := make(chan rune)
+	done := make(chan placementResult, 1)
+	go runPlacement(view, placement, abort, store, rand.New(rand.NewSource(1)))
+	go func() {
+		select {
+		case coords := <-placement:
+			done <- placementResult{coords: coords}
+		case r := <-abort:
+			done <- placementResult{abort: r}
+		case <-time.After(time.Second * 5):
+			close(done)
+		}
+	}()
+	return done
+}
+
+func click(t *testing.T, buttons chan<- string, key string) {
+	t.Helper()
+	select {
+	case buttons <- key:
+	case <-time.After(time.Second * 5):
+		t.Fatalf("button %q was not listened for", key)
+	}
+}
+
+func TestRunPlacement(t *testing.T) {
+	fleet := board.FormatCoords(board.Cells(board.RandomFleet(rand.New(rand.NewSource(2)))))
+	tests := []struct {
+		name   string
+		placed []string
+		// Error of the placed fleet displayed to the player, nil if the fleet is sent.
+		wantErr    error
+		wantCoords []string
+	}{
+		{name: "placed fleet", placed: fleet, wantCoords: fleet},
+		{name: "random fleet when nothing is placed"},
+		{name: "incomplete fleet", placed: fleet[:board.FleetCells-1], wantErr: board.ErrFleetSize},
+		{name: "duplicated cell", placed: append(slices.Clone(fleet[:board.FleetCells-1]), fleet[0]), wantErr: board.ErrDuplicateCoord},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view := fake.NewPlacementView()
+			view.SetShipCoords(tt.placed)
+			done := startPlacement(t, view, layout.NewStore(t.TempDir()))
+			click(t, view.Buttons, cli.PlacementOpt)
+			if tt.placed == nil {
+				// The random fleet is only displayed, and sent with the next click.
+				click(t, view.Buttons, cli.PlacementOpt)
+				if msg, isErr := view.Message(); isErr || !strings.Contains(msg, "Random fleet placed") {
+					t.Errorf("message = %q, error %t, want the random fleet to be confirmed", msg, isErr)
+				}
+				tt.wantCoords = view.ShipCoords()
+			}
+
+			if tt.wantErr != nil {
+				// The next click is only listened for after the error is displayed.
+				click(t, view.Buttons, cli.GoBack)
+				if res := <-done; res.coords != nil {
+					t.Fatalf("invalid fleet was sent: %v", res.coords)
+				}
+				msg, isErr := view.Message()
+				if !isErr || !strings.Contains(msg, tt.wantErr.Error()) {
+					t.Errorf("message = %q, error %t, want the error %q", msg, isErr, tt.wantErr)
+				}
+				return
+			}
+			res := <-done
+			if err := board.ValidateCoords(res.coords); err != nil {
+				t.Fatalf("sent fleet %v is invalid: %s", res.coords, err)
+			}
+			if tt.wantCoords != nil && !slices.Equal(res.coords, tt.wantCoords) {
+				t.Errorf("sent fleet = %v, want %v", res.coords, tt.wantCoords)
+			}
+		})
+	}
+}
+
+func TestRunPlacementLayouts(t *testing.T) {
+	fleet := board.FormatCoords(board.Cells(board.RandomFleet(rand.New(rand.NewSource(3)))))
+	view := fake.NewPlacementView()
+	store := layout.NewStore(t.TempDir())
+	done := startPlacement(t, view, store)
+
+	// A click is only listened for after the previous one was handled, so the view is checked after the next click.
+	view.SetShipCoords(fleet)
+	view.SetLayoutName("corner")
+	click(t, view.Buttons, cli.SaveLayoutOpt)
+	click(t, view.Buttons, cli.LoadLayoutOpt)
+	view.SetShipCoords(nil)
+	click(t, view.Buttons, cli.LayoutOpt("corner"))
+	if names := view.Layouts(); !slices.Equal(names, []string{"corner"}) {
+		t.Errorf("displayed layouts = %v, want [corner]", names)
+	}
+	click(t, view.Buttons, cli.LayoutOpt("missing"))
+	click(t, view.Buttons, cli.PlacementOpt)
+	if msg, isErr := view.Message(); !isErr || !strings.Contains(msg, "Failed to load layout") {
+		t.Errorf("message after loading a missing layout = %q, error %t", msg, isErr)
+	}
+
+	res := <-done
+	got, _ := board.ParseCoords(res.coords)
+	want, _ := board.ParseCoords(fleet)
+	if !slices.EqualFunc(board.Ships(got), board.Ships(want), board.Ship.Equal) {
+		t.Errorf("sent fleet = %v, want the loaded layout %v", res.coords, fleet)
+	}
+}
+
+func TestRunPlacementExportImport(t *testing.T) {
+	fleet := board.FormatCoords(board.Cells(board.RandomFleet(rand.New(rand.NewSource(4)))))
+	view := fake.NewPlacementView()
+	done := startPlacement(t, view, layout.NewStore(t.TempDir()))
+
+	// The extension is added to the typed file.
+	path := filepath.Join(t.TempDir(), "shared")
+	view.SetShipCoords(fleet)
+	view.SetLayoutName(path)
+	click(t, view.Buttons, cli.ExportLayoutOpt)
+	click(t, view.Buttons, cli.RandomizeOpt)
+	if _, err := os.Stat(path + ".txt"); err != nil {
+		t.Errorf("exported file: %s", err)
+	}
+	click(t, view.Buttons, cli.ImportLayoutOpt)
+	view.SetLayoutName(path + ".layout")
+	click(t, view.Buttons, cli.ImportLayoutOpt)
+	click(t, view.Buttons, cli.PlacementOpt)
+	if msg, isErr := view.Message(); !isErr || !strings.Contains(msg, "Failed to import layout") {
+		t.Errorf("message after importing a missing file = %q, error %t", msg, isErr)
+	}
+
+	res := <-done
+	got, _ := board.ParseCoords(res.coords)
+	want, _ := board.ParseCoords(fleet)
+	if !slices.EqualFunc(board.Ships(got), board.Ships(want), board.Ship.Equal) {
+		t.Errorf("sent fleet = %v, want the imported layout %v", res.coords, fleet)
+	}
+}
+
+func TestRunPlacementGoBack(t *testing.T) {
+	view := fake.NewPlacementView()
+	done := startPlacement(t, view, layout.NewStore(t.TempDir()))
+	click(t, view.Buttons, cli.GoBack)
+	if res := <-done; res.abort != ' ' {
+		t.Errorf("abort = %q, want ' '", res.abort)
+	}
+}
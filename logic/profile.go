@@ -0,0 +1,33 @@
+package logic
+
+import (
+	"battleship_client/api/client"
+	"battleship_client/profile"
+)
+
+// Returns the player's profile from the file given in the options.
+// Falls back to the settings of the previous game if the profile is not stored or cannot be loaded.
+func loadProfile(opts Options, settings client.GameSettings) (profile.Profile, error) {
+	fallback := profile.Profile{Nick: settings.Nick, Description: settings.Description}
+	if opts.ProfilePath == "" {
+		return fallback, nil
+	}
+	prof, err := profile.Load(opts.ProfilePath)
+	if err != nil {
+		return fallback, err
+	}
+	return prof, nil
+}
+
+// Applies the changes to the profile stored in the file given in the options. Does nothing if the profile is not stored.
+func updateProfile(opts Options, update func(*profile.Profile)) error {
+	if opts.ProfilePath == "" {
+		return nil
+	}
+	prof, err := profile.Load(opts.ProfilePath)
+	if err != nil {
+		return err
+	}
+	update(&prof)
+	return profile.Save(opts.ProfilePath, prof)
+}
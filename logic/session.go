@@ -0,0 +1,45 @@
+package logic
+
+import (
+	"battleship_client/api/client"
+	"battleship_client/engine"
+	"battleship_client/session"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Returns the stored game if it is still in progress on the server, or nil if there is none.
+// Sessions of games that are over are removed.
+func FindSession(ctx context.Context, opts Options) (*session.Session, error) {
+	if opts.SessionPath == "" {
+		return nil, nil
+	}
+	s, err := session.Load(opts.SessionPath)
+	if err != nil || s == nil {
+		return nil, err
+	}
+	// The token is only valid on the server that issued it.
+	if s.Server != opts.API.BaseURL {
+		return nil, nil
+	}
+	apiClient := client.NewGameClient(opts.API)
+	apiClient.Token = s.Token
+	statusRes, err := apiClient.StatusContext(ctx)
+	if engine.IsSessionLost(err) || (err == nil && statusRes.Status == "ended") {
+		return nil, clearSession(opts, s.Token)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check stored session: %w", err)
+	}
+	return s, nil
+}
+
+// Removes the stored session and the record of its shots.
+func clearSession(opts Options, token string) error {
+	shots, err := opts.shotRecord(token)
+	if err == nil {
+		err = shots.Remove()
+	}
+	return errors.Join(err, session.Clear(opts.SessionPath))
+}
@@ -1,70 +1,178 @@
 package logic
 
 import (
+	"battleship_client/ai"
 	"battleship_client/api/client"
 	"battleship_client/gui/cli"
+	"battleship_client/profile"
+	"battleship_client/session"
 	"context"
 	"fmt"
+	"slices"
 
 	wGui "github.com/RostKoff/warships-gui/v2"
 )
 
 // Displays game settings and listens for button clicks.
 // When the start button or bot button is clicked it sends game settings to the channel given as the argument.
-func DisplayGameSettings(controller *wGui.GUI, ch chan<- client.GameSettings, settings *client.GameSettings) {
-	refresh := make(chan rune)
-
+// The autoplay strategy chosen by the player is stored in the options.
+// The inputs are filled from the player's profile, which is updated when the game is started.
+// If a stored game is still in progress, the player can resume it, which sends its session to the `resume` channel.
+func DisplayGameSettings(controller *wGui.GUI, ch chan<- client.GameSettings, resume chan<- session.Session, settings *client.GameSettings, opts *Options) {
 	controller.NewScreen("settings")
 	controller.SetScreen("settings")
 
-	settingsUi := cli.InitSettings(controller, settings)
+	prof, err := loadProfile(*opts, *settings)
+	if err != nil {
+		controller.Log("Profile error: %s", err)
+	}
+	settingsUi := cli.InitSettings(controller, prof)
+	screens := settingsScreens{
+		leaderboard: func(nick string) {
+			DisplayLeaderboard(controller, opts.API, nick)
+			controller.SetScreen("settings")
+		},
+		history: func() {
+			DisplayHistory(controller, opts.HistoryDir)
+			controller.SetScreen("settings")
+		},
+	}
+	runSettings(settingsUi, ch, resume, prof, opts, screens)
+}
 
-	go displayLobby(settingsUi, refresh)
+// Screens opened from the settings. They return when the player goes back to the settings.
+type settingsScreens struct {
+	leaderboard func(nick string)
+	history     func()
+}
+
+// Handles the clicks on the settings view until a game is started or resumed.
+func runSettings(settingsUi SettingsView, ch chan<- client.GameSettings, resume chan<- session.Session, prof profile.Profile, opts *Options, screens settingsScreens) {
+	refresh := make(chan rune)
+	settingsUi.SetAutoplay(opts.Autoplay)
+
+	// The last opponent is selected again if they are waiting in the lobby.
+	lastOpponent := ""
+	if prof.Mode == profile.ModeOnline {
+		lastOpponent = prof.LastOpponent
+	}
+	go displayLobby(settingsUi, refresh, opts.API, lastOpponent)
 
 	ctx := context.Background()
 	go handleLobby(settingsUi, ctx)
 
+	resumable := make(chan session.Session, 1)
+	// The options are copied, because the clicks below change the autoplay strategy while the session is checked.
+	sessionOpts := *opts
+	go func() {
+		s, err := FindSession(ctx, sessionOpts)
+		if err != nil {
+			settingsUi.Log("Session error: %s", err)
+		}
+		if s != nil {
+			resumable <- *s
+			settingsUi.ShowResume()
+		}
+	}()
+
 	// Handle button clicks.
 	for {
-		clicked := settingsUi.BtnArea.Listen(ctx)
+		clicked := settingsUi.BtnListen(ctx)
 		switch clicked {
 		case "botBtn":
-			ch <- client.GameSettings{
+			gs := client.GameSettings{
 				AgainstBot:  true,
 				Nick:        settingsUi.Nick(),
 				Description: settingsUi.Desc(),
 			}
+			if !startSettings(settingsUi, gs, *opts) {
+				continue
+			}
+			ch <- gs
 			return
 		case "startBtn":
-			ch <- client.GameSettings{
+			gs := client.GameSettings{
 				AgainstBot:  false,
 				Nick:        settingsUi.Nick(),
 				Description: settingsUi.Desc(),
 				TargetNick:  settingsUi.TargetNick(),
 			}
+			if !startSettings(settingsUi, gs, *opts) {
+				continue
+			}
+			ch <- gs
 			return
+		case "resumeBtn":
+			select {
+			case s := <-resumable:
+				resume <- s
+				return
+			default:
+			}
 		case "refreshBtn":
 			settingsUi.ToggleOpponent(settingsUi.TargetNick())
 			refresh <- 'r'
+		case "autoplayBtn":
+			opts.Autoplay = nextAutoplay(opts.Autoplay)
+			settingsUi.SetAutoplay(opts.Autoplay)
+		case "leaderboardBtn":
+			screens.leaderboard(settingsUi.Nick())
+		case "historyBtn":
+			screens.history()
+		}
+	}
+}
+
+// Checks the settings and displays their errors next to the inputs.
+// If they are valid, stores them in the player's profile and returns true.
+func startSettings(ui SettingsView, gs client.GameSettings, opts Options) bool {
+	nickErr := client.ValidateNick(gs.Nick)
+	descErr := client.ValidateDescription(gs.Description)
+	ui.ShowInputErrors(nickErr, descErr)
+	if nickErr != nil || descErr != nil {
+		return false
+	}
+	err := updateProfile(opts, func(p *profile.Profile) {
+		p.Nick = gs.Nick
+		p.Description = gs.Description
+		p.Mode = profile.ModeOnline
+		if gs.AgainstBot {
+			p.Mode = profile.ModeBot
 		}
+	})
+	if err != nil {
+		ui.Log("Profile error: %s", err)
 	}
+	return true
 }
 
 // Fetches game lobbies from the API, displays them on the screen and waits until any rune is sent to the channel given as the argument.
-func displayLobby(ui *cli.SettingsUI, refresh <-chan rune) {
+// The opponent with the given nick is selected after the lobby is displayed for the first time.
+func displayLobby(ui SettingsView, refresh <-chan rune, opts client.Options, selected string) {
 	for {
-		lobbyGames, err := client.Lobby()
+		lobbyGames, err := client.Lobby(opts)
 		if err != nil {
-			ui.Controller.Log(fmt.Sprintf("failed to get game lobby: %s", err.Error()))
+			ui.Log(fmt.Sprintf("failed to get game lobby: %s", err.Error()))
 			lobbyGames = nil
 		}
 		ui.DrawLobbyGames(lobbyGames)
+		if selected != "" {
+			ui.ToggleOpponent(selected)
+			selected = ""
+		}
 		<-refresh
 	}
 }
 
-func handleLobby(ui *cli.SettingsUI, ctx context.Context) {
+func handleLobby(ui SettingsView, ctx context.Context) {
 	for {
 		ui.ListenLobby(ctx)
 	}
 }
+
+// Returns the autoplay strategy that follows the given one, cycling through manual play and all the strategies.
+func nextAutoplay(current string) string {
+	names := append([]string{""}, ai.StrategyNames()...)
+	i := slices.Index(names, current)
+	return names[(i+1)%len(names)]
+}
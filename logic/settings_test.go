@@ -0,0 +1,176 @@
+package logic
+
+import (
+	"battleship_client/ai"
+	"battleship_client/api/client"
+	"battleship_client/gui/fake"
+	"battleship_client/profile"
+	"battleship_client/session"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Result of `runSettings` run on its own goroutine.
+type settingsResult struct {
+	settings *client.GameSettings
+	resumed  *session.Session
+}
+
+// Runs the settings on the fake view in the background. The screens opened from the settings return right away.
+func openSettings(t *testing.T, view *fake.SettingsView, opts *Options) <-chan settingsResult {
+	t.Helper()
+	prof, err := loadProfile(*opts, client.GameSettings{})
+	if err != nil {
+		t.Fatalf("loadProfile() error: %s", err)
+	}
+	ch := make(chan client.GameSettings)
+	resume := make(chan session.Session)
+	done := make(chan settingsResult, 1)
+	screens := settingsScreens{leaderboard: func(string) {}, history: func() {}}
+	go runSettings(view, ch, resume, prof, opts, screens)
+	go func() {
+		select {
+		case gs := <-ch:
+			done <- settingsResult{settings: &gs}
+		case s := <-resume:
+			done <- settingsResult{resumed: &s}
+		case <-time.After(time.Second * 5):
+			close(done)
+		}
+	}()
+	return done
+}
+
+// Polls the condition until it holds, or fails the test after a few seconds.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second * 5)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+}
+
+func TestRunSettingsInputs(t *testing.T) {
+	tests := []struct {
+		name     string
+		nick     string
+		desc     string
+		wantNick bool
+		wantDesc bool
+	}{
+		{name: "valid inputs", nick: "alice", desc: "hello"},
+		{name: "empty inputs", nick: "", desc: ""},
+		{name: "nick too short", nick: "a", desc: "hello", wantNick: true},
+		{name: "description too long", nick: "alice", desc: strings.Repeat("a", client.MaxDescriptionLength+1), wantDesc: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &Options{API: startServer(t), ProfilePath: filepath.Join(t.TempDir(), "profile.json")}
+			view := fake.NewSettingsView(tt.nick, tt.desc)
+			done := openSettings(t, view, opts)
+			click(t, view.Buttons, "botBtn")
+
+			if !tt.wantNick && !tt.wantDesc {
+				res := <-done
+				want := client.GameSettings{AgainstBot: true, Nick: tt.nick, Description: tt.desc}
+				if res.settings == nil || !reflect.DeepEqual(*res.settings, want) {
+					t.Fatalf("sent settings = %+v, want %+v", res.settings, want)
+				}
+				prof, err := profile.Load(opts.ProfilePath)
+				if err != nil {
+					t.Fatalf("profile.Load() error: %s", err)
+				}
+				if want := (profile.Profile{Nick: tt.nick, Description: tt.desc, Mode: profile.ModeBot}); prof != want {
+					t.Errorf("stored profile = %+v, want %+v", prof, want)
+				}
+				return
+			}
+			// A click is only listened for after the previous one was handled, so the errors are checked after the next click.
+			click(t, view.Buttons, "historyBtn")
+			nickErr, descErr := view.InputErrors()
+			if (nickErr != nil) != tt.wantNick || (descErr != nil) != tt.wantDesc {
+				t.Errorf("input errors = %v, %v, want nick error %t and description error %t", nickErr, descErr, tt.wantNick, tt.wantDesc)
+			}
+			select {
+			case res := <-done:
+				t.Fatalf("settings with invalid inputs were sent: %+v", res.settings)
+			default:
+			}
+
+			view.SetInputs("alice", "hello")
+			click(t, view.Buttons, "botBtn")
+			if res := <-done; res.settings == nil || res.settings.Nick != "alice" {
+				t.Errorf("sent settings after fixing the inputs = %+v, want the nick alice", res.settings)
+			}
+		})
+	}
+}
+
+func TestRunSettingsChallenge(t *testing.T) {
+	opts := &Options{API: startServer(t)}
+	if _, err := client.InitGame(client.GameSettings{Nick: "bob"}, opts.API); err != nil {
+		t.Fatalf("InitGame() error: %s", err)
+	}
+	view := fake.NewSettingsView("alice", "")
+	done := openSettings(t, view, opts)
+
+	waitFor(t, "bob in the lobby", func() bool {
+		return slices.ContainsFunc(view.Lobby(), func(g client.LobbyGame) bool { return g.Nick == "bob" })
+	})
+	view.LobbyClicks <- "bob"
+	waitFor(t, "bob to be selected", func() bool { return view.TargetNick() == "bob" })
+	click(t, view.Buttons, "startBtn")
+
+	res := <-done
+	want := client.GameSettings{Nick: "alice", TargetNick: "bob"}
+	if res.settings == nil || !reflect.DeepEqual(*res.settings, want) {
+		t.Errorf("sent settings = %+v, want %+v", res.settings, want)
+	}
+}
+
+func TestRunSettingsAutoplay(t *testing.T) {
+	opts := &Options{API: startServer(t)}
+	view := fake.NewSettingsView("alice", "")
+	done := openSettings(t, view, opts)
+
+	names := ai.StrategyNames()
+	for i, want := range append(slices.Clone(names), "") {
+		click(t, view.Buttons, "autoplayBtn")
+		// Synchronizes with the handling of the previous click.
+		click(t, view.Buttons, "historyBtn")
+		if opts.Autoplay != want || view.Autoplay() != want {
+			t.Errorf("autoplay after %d clicks = %q, displayed %q, want %q", i+1, opts.Autoplay, view.Autoplay(), want)
+		}
+	}
+	click(t, view.Buttons, "botBtn")
+	<-done
+}
+
+func TestRunSettingsResume(t *testing.T) {
+	api := startServer(t)
+	game, err := client.InitGame(client.GameSettings{Nick: "alice", AgainstBot: true}, api)
+	if err != nil {
+		t.Fatalf("InitGame() error: %s", err)
+	}
+	stored := session.Session{Token: game.Token, Server: api.BaseURL, Nick: "alice", AgainstBot: true}
+	opts := &Options{API: api, SessionPath: filepath.Join(t.TempDir(), "session.json")}
+	if _, err := session.Create(opts.SessionPath, stored); err != nil {
+		t.Fatalf("session.Create() error: %s", err)
+	}
+	view := fake.NewSettingsView("alice", "")
+	done := openSettings(t, view, opts)
+
+	waitFor(t, "the resume button", view.ResumeShown)
+	click(t, view.Buttons, "resumeBtn")
+	res := <-done
+	if res.resumed == nil || res.resumed.Token != stored.Token {
+		t.Errorf("resumed session = %+v, want the token %q", res.resumed, stored.Token)
+	}
+}
@@ -0,0 +1,56 @@
+package logic
+
+import (
+	"battleship_client/api/client"
+	"battleship_client/board"
+	"battleship_client/engine"
+	"battleship_client/gui/cli"
+	"context"
+
+	wGui "github.com/RostKoff/warships-gui/v2"
+)
+
+// Displays the summary of the finished game on a separate screen and returns the option chosen by the player, e.g. `cli.RematchOpt`.
+func DisplaySummary(controller *wGui.GUI, summary engine.Summary) string {
+	controller.NewScreen("summary")
+	controller.SetScreen("summary")
+	defer controller.RemoveScreen("summary")
+
+	ui := cli.InitSummary(controller, summary)
+	ctx := context.Background()
+	for {
+		switch opt := ui.Listen(ctx); opt {
+		case cli.RematchOpt, cli.NewGameOpt, cli.QuitOpt:
+			return opt
+		}
+	}
+}
+
+// Displays the summary of the finished game and routes the player's choice back to the main loop.
+// A rematch is sent to the `rematch` channel, a new game and quitting are sent to `abandon` as ' ' and 'q'.
+func finishGame(controller *wGui.GUI, summary engine.Summary, gs client.GameSettings, abandon chan<- rune, rematch chan<- client.GameSettings) {
+	switch DisplaySummary(controller, summary) {
+	case cli.RematchOpt:
+		rematch <- rematchSettings(gs, summary)
+	case cli.QuitOpt:
+		abandon <- 'q'
+	default:
+		abandon <- ' '
+	}
+}
+
+// Returns the settings that start the game again with the same fleet. Online, the opponent of the finished game is challenged,
+// and the game starts as soon as they challenge the player back.
+func rematchSettings(gs client.GameSettings, summary engine.Summary) client.GameSettings {
+	gs.TargetNick = ""
+	if !gs.AgainstBot {
+		gs.TargetNick = summary.Opponent
+	}
+	gs.Coords = make([]string, 0, board.FleetCells)
+	for _, c := range board.AllCoords() {
+		if summary.PlayerBoard.At(c).IsShip() {
+			gs.Coords = append(gs.Coords, c.String())
+		}
+	}
+	return gs
+}
@@ -0,0 +1,83 @@
+package logic
+
+import (
+	"battleship_client/api/client"
+	"battleship_client/board"
+	"battleship_client/gui/cli"
+	"context"
+	"math/rand"
+	"time"
+)
+
+var (
+	_ GameView      = (*cli.GameUI)(nil)
+	_ PlacementView = (*cli.PlacementUI)(nil)
+	_ SettingsView  = (*cli.SettingsUI)(nil)
+)
+
+// Screen of the game in progress. Implemented by `cli.GameUI`, and by `fake.GameView` to run the game without a terminal.
+type GameView interface {
+	Log(format string, args ...any)
+	// Displays the message that the game has not started yet.
+	ShowWaiting()
+	// Hides the waiting message and displays the boards.
+	Show()
+	DrawShips(coords []string) error
+	DrawNicks(pNick string, oppNick string)
+	DrawDescriptions(pDesc string, oppDesc string)
+	SetTurn(text string)
+	SetTimer(text string)
+	// Displays the time left in the player's turn, highlighted as it runs low.
+	SetCountdown(left time.Duration)
+	SetEnd(text string)
+	// Displays the health of the connection, given by the number of failed requests in a row, and the time of the last status.
+	SetConnection(failures int, lastPoll time.Time)
+	// Displays the error message, or hides it if the message is empty.
+	ShowError(message string)
+	HandleOppShots(pShips []string, oppShots []string) error
+	HandlePShot(fireResponse string, coord string) error
+	RestoreOppBoard(grid board.Grid, hit int, miss int)
+	CalculateAccuracy()
+	// Returns a copy of the opponent's board. It is safe to call from any goroutine, e.g. by the autoplay or the auto-fire,
+	// while the game events update the board.
+	OppGrid() board.Grid
+	ToggleHint()
+	// Returns the coordinate chosen by the player, or an empty one when the context is done.
+	ListenForShot(ctx context.Context) (string, error)
+	// Returns the key of the clicked button, e.g. `cli.AbandonOpt`.
+	BtnListen(ctx context.Context) string
+}
+
+// Screen where the player places their fleet. Implemented by `cli.PlacementUI` and `fake.PlacementView`.
+type PlacementView interface {
+	// Returns the key of the clicked button, e.g. `cli.PlacementOpt`.
+	SetBtnListen(ctx context.Context) string
+	// Handles a single click on the board or the ship counters.
+	Listen(ctx context.Context) error
+	Randomize(rng *rand.Rand)
+	ShipCoords() []string
+	SetShips(ships []board.Ship)
+	ShowError(message string)
+	ShowInfo(message string)
+	LayoutName() string
+	LayoutsShown() bool
+	ShowLayouts(names []string)
+	HideLayouts()
+}
+
+// Screen with the game settings and the lobby. Implemented by `cli.SettingsUI` and `fake.SettingsView`.
+type SettingsView interface {
+	Log(format string, args ...any)
+	// Returns the key of the clicked button, e.g. "startBtn".
+	BtnListen(ctx context.Context) string
+	Nick() string
+	Desc() string
+	TargetNick() string
+	ToggleOpponent(nick string)
+	SetAutoplay(strategy string)
+	ShowResume()
+	ShowInputErrors(nickErr error, descErr error)
+	DrawLobbyGames(lobbyGames []client.LobbyGame)
+	// Handles a single click in the lobby.
+	ListenLobby(ctx context.Context)
+}
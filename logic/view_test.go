@@ -0,0 +1,9 @@
+package logic
+
+import "battleship_client/gui/fake"
+
+var (
+	_ GameView      = (*fake.GameView)(nil)
+	_ PlacementView = (*fake.PlacementView)(nil)
+	_ SettingsView  = (*fake.SettingsView)(nil)
+)
@@ -1,30 +1,94 @@
 package main
 
 import (
+	"battleship_client/ai"
 	"battleship_client/api/client"
+	"battleship_client/history"
+	"battleship_client/layout"
 	"battleship_client/logic"
+	"battleship_client/profile"
+	"battleship_client/session"
+	"battleship_client/shotlog"
 	"context"
+	"flag"
+	"log"
+	"os"
+	"strings"
 
 	wGui "github.com/RostKoff/warships-gui/v2"
 )
 
+// Commands that are run instead of the game when their name is given as the first argument.
+var commands = map[string]func(args []string) error{
+	"serve":    serve,
+	"simulate": simulate,
+	"layouts":  layouts,
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		if command, ok := commands[os.Args[1]]; ok {
+			if err := command(os.Args[2:]); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+	}
+
+	opts := logic.Options{API: client.DefaultOptions()}
+	if dir, err := history.DefaultDir(); err == nil {
+		opts.HistoryDir = dir
+	}
+	if dir, err := layout.DefaultDir(); err == nil {
+		opts.LayoutDir = dir
+	}
+	if path, err := profile.DefaultPath(); err == nil {
+		opts.ProfilePath = path
+	}
+	if path, err := session.DefaultPath(); err == nil {
+		opts.SessionPath = path
+	}
+	if dir, err := shotlog.DefaultDir(); err == nil {
+		opts.ShotDir = dir
+	}
+	if server := os.Getenv(client.ServerEnv); server != "" {
+		opts.API.BaseURL = server
+	}
+	flag.StringVar(&opts.API.BaseURL, "server", opts.API.BaseURL, "address of the game server API (env "+client.ServerEnv+")")
+	flag.DurationVar(&opts.API.Timeout, "timeout", opts.API.Timeout, "timeout of a single request to the server")
+	flag.IntVar(&opts.API.RetryMax, "retries", opts.API.RetryMax, "maximum number of retries of a failed request, 0 disables retries and a negative value uses the default")
+	flag.StringVar(&opts.Autoplay, "autoplay", "", "strategy that fires instead of the player: "+strings.Join(ai.StrategyNames(), ", "))
+	flag.BoolVar(&opts.AutoFire, "autofire", false, "fire at a random cell when the turn is about to run out")
+	flag.StringVar(&opts.HistoryDir, "history", opts.HistoryDir, "directory where the games are recorded, empty disables recording")
+	flag.StringVar(&opts.LayoutDir, "layouts", opts.LayoutDir, "directory where the placement layouts are saved")
+	flag.StringVar(&opts.ProfilePath, "profile", opts.ProfilePath, "file where the nick and the description are stored, empty disables storing")
+	flag.StringVar(&opts.SessionPath, "session", opts.SessionPath, "file where the game in progress is stored to be resumed, empty disables resuming")
+	flag.Parse()
+	if opts.Autoplay != "" {
+		if _, err := ai.NewStrategy(opts.Autoplay, nil); err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	controller := wGui.NewGUI(true)
 	boardCh := make(chan []string)
 	settingsCh := make(chan client.GameSettings)
+	resumeCh := make(chan session.Session)
+	// Settings of the rematches chosen on the summary screen after the game.
+	rematchCh := make(chan client.GameSettings)
 	settings := client.GameSettings{}
 	board := make([]string, 0)
 	abort := make(chan rune)
 	for {
 		ctx, canc := context.WithCancel(context.Background())
 		var char rune
-		go logic.DisplayGameSettings(controller, settingsCh, &settings)
+		go logic.DisplayGameSettings(controller, settingsCh, resumeCh, &settings, &opts)
 		go func(ctx context.Context) {
 			select {
 			case <-ctx.Done():
 				return
 			case settings = <-settingsCh:
-				logic.DisplayPlacement(controller, boardCh, abort)
+				logic.DisplayPlacement(controller, boardCh, abort, opts)
 			}
 
 		}(ctx)
@@ -34,7 +98,26 @@ func main() {
 				return
 			case board = <-boardCh:
 				settings.Coords = board
-				logic.StartGame(controller, settings, abort)
+				logic.StartGame(controller, settings, opts, abort, rematchCh)
+			}
+		}(ctx)
+		go func(ctx context.Context) {
+			select {
+			case <-ctx.Done():
+				return
+			case s := <-resumeCh:
+				logic.ResumeGame(controller, s, opts, abort, rematchCh)
+			}
+		}(ctx)
+		// A rematch starts the game right away, without the settings and the placement.
+		go func(ctx context.Context) {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case gs := <-rematchCh:
+					go logic.StartGame(controller, gs, opts, abort, rematchCh)
+				}
 			}
 		}(ctx)
 		go func(ctx context.Context) {
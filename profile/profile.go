@@ -0,0 +1,62 @@
+// Package profile keeps the player's details between the launches of the client.
+package profile
+
+import (
+	"battleship_client/xdg"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Modes of the last game started by the player.
+const (
+	ModeBot    = "bot"
+	ModeOnline = "online"
+)
+
+type Profile struct {
+	Nick        string `json:"nick"`
+	Description string `json:"desc"`
+	// Mode of the last started game, `ModeBot` or `ModeOnline`.
+	Mode string `json:"mode,omitempty"`
+	// Nick of the opponent in the last online game.
+	LastOpponent string `json:"last_opponent,omitempty"`
+}
+
+// Returns the default path of the profile file, e.g. ~/.config/battleship_client/profile.json.
+func DefaultPath() (string, error) {
+	dir, err := xdg.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "profile.json"), nil
+}
+
+// Loads the profile from the file. A missing file means an empty profile.
+func Load(path string) (Profile, error) {
+	p := Profile{}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return p, nil
+	}
+	if err != nil {
+		return p, fmt.Errorf("failed to read profile: %w", err)
+	}
+	if err := json.Unmarshal(data, &p); err != nil {
+		return p, fmt.Errorf("failed to unmarshal profile: %w", err)
+	}
+	return p, nil
+}
+
+// Saves the profile to the file, creating its directory if needed. See `xdg.WriteFile`.
+func Save(path string, p Profile) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal profile: %w", err)
+	}
+	if err := xdg.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to save profile: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProfileRoundTrip(t *testing.T) {
+	config := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", config)
+	path, err := DefaultPath()
+	if err != nil {
+		t.Fatalf("DefaultPath() error: %s", err)
+	}
+	if want := filepath.Join(config, "battleship_client", "profile.json"); path != want {
+		t.Errorf("DefaultPath() = %s, want %s", path, want)
+	}
+	if p, err := Load(path); err != nil || p != (Profile{}) {
+		t.Fatalf("Load() of a missing profile = %+v, %v, want an empty profile", p, err)
+	}
+
+	want := Profile{Nick: "alice", Description: "Żeglarka", Mode: ModeOnline, LastOpponent: "bob"}
+	if err := Save(path, want); err != nil {
+		t.Fatalf("Save() error: %s", err)
+	}
+	if p, err := Load(path); err != nil || p != want {
+		t.Errorf("Load() = %+v, %v, want %+v", p, err, want)
+	}
+	want.Mode = ModeBot
+	want.LastOpponent = ""
+	if err := Save(path, want); err != nil {
+		t.Fatalf("second Save() error: %s", err)
+	}
+	if p, err := Load(path); err != nil || p != want {
+		t.Errorf("Load() after the second Save() = %+v, %v, want %+v", p, err, want)
+	}
+}
+
+func TestLoadInvalidProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profile.json")
+	if err := os.WriteFile(path, []byte(`{"nick": 1}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Errorf("Load() error = nil, want an error")
+	}
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"battleship_client/api/server"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+// Runs the local stand-in game server until it fails.
+func serve(args []string) error {
+	opts := server.DefaultOptions()
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", "localhost:8080", "address the server listens on")
+	fs.DurationVar(&opts.TurnTime, "turn", opts.TurnTime, "time a player has for a single shot")
+	fs.DurationVar(&opts.WaitTimeout, "wait", opts.WaitTimeout, "time after which a waiting game that was not refreshed is removed")
+	fs.DurationVar(&opts.BotDelay, "bot-delay", opts.BotDelay, "time the bot waits before each shot")
+	fs.Int64Var(&opts.Seed, "seed", opts.Seed, "seed of the random generator, 0 for a random seed")
+	fs.Parse(args)
+
+	log.Printf("serving the game API at http://%s/api", *addr)
+	if err := http.ListenAndServe(*addr, server.New(opts)); err != nil {
+		return fmt.Errorf("failed to serve: %w", err)
+	}
+	return nil
+}
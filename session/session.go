@@ -0,0 +1,115 @@
+// Package session persists the game in progress, so it can be resumed after the client is closed.
+package session
+
+import (
+	"battleship_client/xdg"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Game in progress, identified by its token on the server.
+// The player's shots are kept separately, in the shot record of the token.
+type Session struct {
+	Token      string    `json:"token"`
+	Server     string    `json:"server"`
+	Nick       string    `json:"nick"`
+	AgainstBot bool      `json:"wpbot"`
+	StartedAt  time.Time `json:"started_at"`
+	// File the game is recorded to in the history, empty if it is not recorded.
+	HistoryPath string `json:"history_path,omitempty"`
+}
+
+// Returns the default path of the session file, e.g. ~/.local/state/battleship_client/session.json.
+func DefaultPath() (string, error) {
+	dir, err := xdg.StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "session.json"), nil
+}
+
+// Loads the session from the file. Returns nil if there is no session.
+func Load(path string) (*Session, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session: %w", err)
+	}
+	s := Session{}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal session: %w", err)
+	}
+	return &s, nil
+}
+
+// Removes the session file. A missing file is not an error.
+func Clear(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove session: %w", err)
+	}
+	return nil
+}
+
+// Keeps the session file up to date while the game is played.
+// All methods can be called on a nil store, in which case they do nothing.
+type Store struct {
+	mu      sync.Mutex
+	path    string
+	session Session
+}
+
+// Writes the session to the file and returns the store that updates it.
+func Create(path string, s Session) (*Store, error) {
+	st := &Store{path: path, session: s}
+	if err := st.save(); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+// Returns a copy of the stored session.
+func (st *Store) Session() Session {
+	if st == nil {
+		return Session{}
+	}
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.session
+}
+
+func (st *Store) SetHistoryPath(path string) error {
+	if st == nil {
+		return nil
+	}
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.session.HistoryPath = path
+	return st.save()
+}
+
+// Removes the session file after the game is over.
+func (st *Store) Clear() error {
+	if st == nil {
+		return nil
+	}
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return Clear(st.path)
+}
+
+func (st *Store) save() error {
+	data, err := json.Marshal(st.session)
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+	if err := xdg.WriteFile(st.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+	return nil
+}
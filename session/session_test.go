@@ -0,0 +1,83 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "session.json")
+	want := Session{
+		Token:      "token",
+		Server:     "https://example.com/api",
+		Nick:       "alice",
+		AgainstBot: true,
+		StartedAt:  time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+	st, err := Create(path, want)
+	if err != nil {
+		t.Fatalf("Create() error: %s", err)
+	}
+	if got := st.Session(); got != want {
+		t.Errorf("Session() = %+v, want %+v", got, want)
+	}
+	got, err := Load(path)
+	if err != nil || got == nil || *got != want {
+		t.Fatalf("Load() = %+v, %v, want %+v", got, err, want)
+	}
+
+	if err := st.SetHistoryPath("/history/game.jsonl"); err != nil {
+		t.Fatalf("SetHistoryPath() error: %s", err)
+	}
+	want.HistoryPath = "/history/game.jsonl"
+	if got, err := Load(path); err != nil || got == nil || *got != want {
+		t.Errorf("Load() after SetHistoryPath() = %+v, %v, want %+v", got, err, want)
+	}
+
+	if err := st.Clear(); err != nil {
+		t.Fatalf("Clear() error: %s", err)
+	}
+	if got, err := Load(path); got != nil || err != nil {
+		t.Errorf("Load() of the cleared session = %+v, %v, want nil", got, err)
+	}
+	if err := Clear(path); err != nil {
+		t.Errorf("Clear() of the missing session error: %s", err)
+	}
+}
+
+func TestLoadInvalidSession(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := os.WriteFile(path, []byte(`{"token":`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if s, err := Load(path); s != nil || err == nil {
+		t.Errorf("Load() = %+v, %v, want an error", s, err)
+	}
+}
+
+func TestCreateError(t *testing.T) {
+	// The parent of the session file is a file, so the directory cannot be created.
+	parent := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(parent, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if st, err := Create(filepath.Join(parent, "session.json"), Session{Token: "token"}); st != nil || err == nil {
+		t.Errorf("Create() = %v, %v, want an error", st, err)
+	}
+}
+
+// The game is played without a stored session when it could not be created.
+func TestNilStore(t *testing.T) {
+	var st *Store
+	if s := st.Session(); s != (Session{}) {
+		t.Errorf("Session() = %+v, want an empty session", s)
+	}
+	if err := st.SetHistoryPath("/history/game.jsonl"); err != nil {
+		t.Errorf("SetHistoryPath() error: %s", err)
+	}
+	if err := st.Clear(); err != nil {
+		t.Errorf("Clear() error: %s", err)
+	}
+}
@@ -0,0 +1,176 @@
+// Package shotlog keeps the record of the player's shots in a game and their results,
+// from which the opponent's board can be rebuilt at any time, e.g. after the game is resumed.
+package shotlog
+
+import (
+	"battleship_client/board"
+	"battleship_client/xdg"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+var ErrAlreadyShot = errors.New("coord was already shot")
+
+// Shot fired by the player and its result returned by the server.
+type Shot struct {
+	Coord  string `json:"coord"`
+	Result string `json:"result"`
+}
+
+// Record of the shots of a single game, stored in a file named after the game token.
+// The record is safe for concurrent use.
+type Record struct {
+	mu sync.Mutex
+	// Empty if the record is only kept in memory.
+	path    string
+	shots   []Shot
+	results map[board.Coord]string
+}
+
+// Returns the default directory with the records, e.g. ~/.local/state/battleship_client/shots.
+func DefaultDir() (string, error) {
+	dir, err := xdg.StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "shots"), nil
+}
+
+// Creates a record that is not stored in a file.
+func New() *Record {
+	return &Record{results: make(map[board.Coord]string)}
+}
+
+// Opens the record of the game with the token stored in the directory, or creates an empty one if there is none.
+// An empty directory means the record is only kept in memory.
+func Open(dir string, token string) (*Record, error) {
+	r := New()
+	if dir == "" {
+		return r, nil
+	}
+	// The token is hashed, so it is not kept in the file name and any characters are allowed.
+	sum := sha256.Sum256([]byte(token))
+	r.path = filepath.Join(dir, hex.EncodeToString(sum[:8])+".json")
+	data, err := os.ReadFile(r.path)
+	if os.IsNotExist(err) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read shot record: %w", err)
+	}
+	shots := make([]Shot, 0)
+	if err := json.Unmarshal(data, &shots); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal shot record: %w", err)
+	}
+	for _, shot := range shots {
+		if err := r.add(shot); err != nil {
+			return nil, fmt.Errorf("invalid shot record: %w", err)
+		}
+	}
+	return r, nil
+}
+
+// Adds the shot to the record and stores it. A coordinate can only be shot once.
+func (r *Record) Add(coord string, result string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.add(Shot{Coord: coord, Result: result}); err != nil {
+		return err
+	}
+	return r.save()
+}
+
+// Returns the result of the shot at the coordinate, or false if it was not shot.
+func (r *Record) Result(c board.Coord) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result, ok := r.results[c]
+	return result, ok
+}
+
+// Returns all the shots in the order they were fired.
+func (r *Record) Shots() []Shot {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Shot(nil), r.shots...)
+}
+
+// Rebuilds the opponent's board from the results of the shots, including the missed cells around the sunk ships.
+func (r *Record) Grid() board.Grid {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	grid := board.Grid{}
+	for _, shot := range r.shots {
+		c, _ := board.ParseCoord(shot.Coord)
+		grid.ApplyResult(c, shot.Result)
+	}
+	return grid
+}
+
+// Returns the number of shots with each result.
+func (r *Record) Counts() (hit int, miss int, sunk int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, shot := range r.shots {
+		switch shot.Result {
+		case board.ResultHit:
+			hit++
+		case board.ResultMiss:
+			miss++
+		case board.ResultSunk:
+			sunk++
+		}
+	}
+	return hit, miss, sunk
+}
+
+// Removes the stored record after the game is over. The record in memory is kept.
+func (r *Record) Remove() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.path == "" {
+		return nil
+	}
+	if err := os.Remove(r.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove shot record: %w", err)
+	}
+	return nil
+}
+
+func (r *Record) add(shot Shot) error {
+	c, err := board.ParseCoord(shot.Coord)
+	if err != nil {
+		return fmt.Errorf("invalid coord %q: %w", shot.Coord, err)
+	}
+	switch shot.Result {
+	case board.ResultHit, board.ResultMiss, board.ResultSunk:
+	default:
+		return fmt.Errorf("unknown shot result %q", shot.Result)
+	}
+	if _, ok := r.results[c]; ok {
+		return fmt.Errorf("%w: %s", ErrAlreadyShot, shot.Coord)
+	}
+	r.results[c] = shot.Result
+	r.shots = append(r.shots, shot)
+	return nil
+}
+
+func (r *Record) save() error {
+	if r.path == "" {
+		return nil
+	}
+	data, err := json.Marshal(r.shots)
+	if err != nil {
+		return fmt.Errorf("failed to marshal shot record: %w", err)
+	}
+	if err := xdg.WriteFile(r.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to save shot record: %w", err)
+	}
+	return nil
+}
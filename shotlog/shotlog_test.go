@@ -0,0 +1,180 @@
+package shotlog
+
+import (
+	"battleship_client/board"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// Shots sinking the two-tile ship at A1-A2, with a miss and a hit of another ship.
+var testShots = []Shot{
+	{Coord: "A1", Result: board.ResultHit},
+	{Coord: "E5", Result: board.ResultMiss},
+	{Coord: "A2", Result: board.ResultSunk},
+	{Coord: "J10", Result: board.ResultHit},
+}
+
+func addShots(t *testing.T, r *Record, shots []Shot) {
+	t.Helper()
+	for _, shot := range shots {
+		if err := r.Add(shot.Coord, shot.Result); err != nil {
+			t.Fatalf("Add(%s, %s) error: %s", shot.Coord, shot.Result, err)
+		}
+	}
+}
+
+func openRecord(t *testing.T, dir, token string) *Record {
+	t.Helper()
+	r, err := Open(dir, token)
+	if err != nil {
+		t.Fatalf("Open(%q) error: %s", token, err)
+	}
+	return r
+}
+
+func coord(s string) board.Coord {
+	c, _ := board.ParseCoord(s)
+	return c
+}
+
+func TestRecordPersistence(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "shots")
+	r := openRecord(t, dir, "token-a")
+	if shots := r.Shots(); len(shots) != 0 {
+		t.Fatalf("new record has shots %v", shots)
+	}
+	addShots(t, r, testShots)
+	// The record of another game is kept apart.
+	addShots(t, openRecord(t, dir, "token-b"), testShots[:1])
+
+	reopened := openRecord(t, dir, "token-a")
+	if shots := reopened.Shots(); !slices.Equal(shots, testShots) {
+		t.Errorf("reopened shots = %v, want %v", shots, testShots)
+	}
+	if result, ok := reopened.Result(coord("A2")); !ok || result != board.ResultSunk {
+		t.Errorf("Result(A2) = %q, %t, want %q", result, ok, board.ResultSunk)
+	}
+	if shots := openRecord(t, dir, "token-b").Shots(); len(shots) != 1 {
+		t.Errorf("shots of the other game = %v, want 1 shot", shots)
+	}
+	// The token is not a part of the file name.
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		if strings.Contains(e.Name(), "token") {
+			t.Errorf("file %s contains the token", e.Name())
+		}
+	}
+
+	if err := reopened.Remove(); err != nil {
+		t.Fatalf("Remove() error: %s", err)
+	}
+	if err := reopened.Remove(); err != nil {
+		t.Errorf("second Remove() error: %s", err)
+	}
+	// The record in memory is kept after the file is removed.
+	if len(reopened.Shots()) != len(testShots) {
+		t.Errorf("shots in memory were removed")
+	}
+	if shots := openRecord(t, dir, "token-a").Shots(); len(shots) != 0 {
+		t.Errorf("shots of the removed record = %v", shots)
+	}
+}
+
+func TestRecordInMemory(t *testing.T) {
+	dir := t.TempDir()
+	r := openRecord(t, "", "token")
+	addShots(t, r, testShots)
+	if err := r.Remove(); err != nil {
+		t.Errorf("Remove() error: %s", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("record in memory wrote %d files", len(entries))
+	}
+}
+
+func TestRecordAdd(t *testing.T) {
+	tests := []struct {
+		name    string
+		shot    Shot
+		wantErr string
+	}{
+		{name: "shot again", shot: Shot{Coord: "A1", Result: board.ResultMiss}, wantErr: ErrAlreadyShot.Error()},
+		{name: "invalid coord", shot: Shot{Coord: "K1", Result: board.ResultMiss}, wantErr: "invalid coord"},
+		{name: "unknown result", shot: Shot{Coord: "B1", Result: "blocked"}, wantErr: "unknown shot result"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := openRecord(t, t.TempDir(), "token")
+			addShots(t, r, testShots[:1])
+			err := r.Add(tt.shot.Coord, tt.shot.Result)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Add(%s, %s) error = %v, want %q", tt.shot.Coord, tt.shot.Result, err, tt.wantErr)
+			}
+			if shots := r.Shots(); len(shots) != 1 {
+				t.Errorf("shots after the rejected one = %v", shots)
+			}
+		})
+	}
+	if err := New().Add("A1", board.ResultHit); err != nil {
+		t.Errorf("Add() to a record in memory error: %s", err)
+	}
+}
+
+func TestOpenInvalidRecord(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "not json", data: "[{"},
+		{name: "shot twice", data: `[{"coord":"A1","result":"hit"},{"coord":"A1","result":"miss"}]`},
+		{name: "unknown result", data: `[{"coord":"A1","result":"blocked"}]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			addShots(t, openRecord(t, dir, "token"), testShots[:1])
+			entries, _ := os.ReadDir(dir)
+			if len(entries) != 1 {
+				t.Fatalf("directory has %d files, want 1", len(entries))
+			}
+			if err := os.WriteFile(filepath.Join(dir, entries[0].Name()), []byte(tt.data), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := Open(dir, "token"); err == nil {
+				t.Errorf("Open() error = nil, want an error")
+			}
+		})
+	}
+}
+
+// The opponent's board is rebuilt with the cells around the sunk ship marked as missed.
+func TestRecordGrid(t *testing.T) {
+	r := openRecord(t, t.TempDir(), "token")
+	addShots(t, r, testShots)
+	grid := r.Grid()
+	tests := []struct {
+		coord string
+		want  board.Cell
+	}{
+		{coord: "A1", want: board.Sunk},
+		{coord: "A2", want: board.Sunk},
+		{coord: "B1", want: board.Miss},
+		{coord: "B3", want: board.Miss},
+		{coord: "A3", want: board.Miss},
+		{coord: "E5", want: board.Miss},
+		{coord: "J10", want: board.Hit},
+		{coord: "I9", want: board.Empty},
+		{coord: "C1", want: board.Empty},
+	}
+	for _, tt := range tests {
+		if got := grid.At(coord(tt.coord)); got != tt.want {
+			t.Errorf("cell %s = %v, want %v", tt.coord, got, tt.want)
+		}
+	}
+	if hit, miss, sunk := r.Counts(); hit != 2 || miss != 1 || sunk != 1 {
+		t.Errorf("Counts() = %d, %d, %d, want 2, 1, 1", hit, miss, sunk)
+	}
+}
@@ -0,0 +1,89 @@
+package sim
+
+import (
+	"battleship_client/ai"
+	"battleship_client/board"
+	"fmt"
+)
+
+// Guards against strategies that never finish, e.g. firing at the same cell repeatedly.
+const maxShots = board.Size * board.Size
+
+// One side of a simulated game.
+type side struct {
+	strategy ai.Strategy
+	// The side's own fleet.
+	ships []board.Ship
+	// Results of the side's shots at the opponent's board, as the side sees them.
+	view  board.Grid
+	shots int
+	hits  int
+}
+
+// Result of a single simulated game.
+type gameResult struct {
+	winner int
+	shots  [2]int
+	hits   [2]int
+	turns  int
+}
+
+// Plays a game between the two sides, starting with the given one.
+// Follows the rules of the server: a player who hits fires again, a player who misses passes the turn.
+func playGame(sides [2]*side, first int) (gameResult, error) {
+	res := gameResult{}
+	turn := first
+	for {
+		res.turns++
+		shooter, target := sides[turn], sides[1-turn]
+		for {
+			if shooter.shots >= maxShots {
+				return res, fmt.Errorf("strategy did not finish the game in %d shots", maxShots)
+			}
+			c, err := shooter.strategy.Next(shooter.view)
+			if err != nil {
+				return res, fmt.Errorf("failed to choose target: %w", err)
+			}
+			if shooter.view.At(c) != board.Empty {
+				return res, fmt.Errorf("strategy fired at %s twice", c)
+			}
+			shooter.shots++
+			if !fire(shooter, target, c) {
+				break
+			}
+			shooter.hits++
+			if len(shooter.view.SunkShips()) == len(target.ships) {
+				res.winner = turn
+				for i, s := range sides {
+					res.shots[i] = s.shots
+					res.hits[i] = s.hits
+				}
+				return res, nil
+			}
+		}
+		turn = 1 - turn
+	}
+}
+
+// Applies the shot of the shooter at the target's board and reports whether it hit a ship.
+// Sunk ships are marked on the shooter's view the same way the game screen marks them.
+func fire(shooter, target *side, c board.Coord) bool {
+	for _, ship := range target.ships {
+		for _, cell := range ship {
+			if cell != c {
+				continue
+			}
+			shooter.view.Set(c, board.Hit)
+			sunk := true
+			for _, s := range ship {
+				sunk = sunk && shooter.view.At(s) == board.Hit
+			}
+			if sunk {
+				shooter.view.MarkSunk(c)
+			}
+			return true
+		}
+	}
+	shooter.view.Set(c, board.Miss)
+	return false
+}
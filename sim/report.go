@@ -0,0 +1,44 @@
+package sim
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Width of the longest bar of the distribution in the text report.
+const barWidth = 40
+
+// Writes the report in a human readable form, with the distributions drawn as bar charts.
+func (r Report) WriteText(w io.Writer) error {
+	b := strings.Builder{}
+	fmt.Fprintf(&b, "Games: %d (seed %d), mean turns per game: %.1f\n", r.Games, r.Seed, r.MeanTurns)
+	for i, p := range r.Players {
+		fmt.Fprintf(&b, "\nPlayer %d: strategy %s, placement %s\n", i+1, p.Strategy, p.Placement)
+		fmt.Fprintf(&b, "  wins:          %d (%.1f%%)\n", p.Wins, p.WinRate*100)
+		fmt.Fprintf(&b, "  accuracy:      %.1f%%\n", p.Accuracy*100)
+		if p.Wins == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "  shots to win:  mean %.1f, median %.1f, min %d, max %d\n",
+			p.MeanShotsToWin, p.MedianShotsToWin, p.MinShotsToWin, p.MaxShotsToWin)
+		most := 0
+		for _, bucket := range p.Distribution {
+			most = max(most, bucket.Count)
+		}
+		for _, bucket := range p.Distribution {
+			bar := strings.Repeat("#", bucket.Count*barWidth/most)
+			fmt.Fprintf(&b, "  %3d-%-3d %6d %s\n", bucket.From, bucket.To, bucket.Count, bar)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Writes the report as indented JSON.
+func (r Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
@@ -0,0 +1,199 @@
+// Package sim plays games between targeting and placement strategies without any user interface or network,
+// and reports statistics that allow comparing the strategies.
+package sim
+
+import (
+	"battleship_client/ai"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// Width of the buckets of the shots-to-win distribution.
+const bucketSize = 5
+
+// Strategies used by one of the simulated players.
+type Player struct {
+	Strategy  string `json:"strategy"`
+	Placement string `json:"placement"`
+}
+
+type Config struct {
+	Players [2]Player
+	// Number of games to play. The players take turns in starting the games.
+	Games int
+	// Seed of the random generators. The same seed and configuration always give the same report.
+	Seed int64
+	// Number of games played at the same time. Zero means the number of CPUs.
+	Workers int
+}
+
+type Report struct {
+	Games   int            `json:"games"`
+	Seed    int64          `json:"seed"`
+	Players [2]PlayerStats `json:"players"`
+	// Mean number of turns, i.e. changes of the shooting player, in a game.
+	MeanTurns float64 `json:"mean_turns"`
+}
+
+type PlayerStats struct {
+	Player
+	Wins    int     `json:"wins"`
+	WinRate float64 `json:"win_rate"`
+	// Number of shots the player needed to sink the whole opposing fleet, in the games they won.
+	MeanShotsToWin   float64 `json:"mean_shots_to_win"`
+	MedianShotsToWin float64 `json:"median_shots_to_win"`
+	MinShotsToWin    int     `json:"min_shots_to_win"`
+	MaxShotsToWin    int     `json:"max_shots_to_win"`
+	// Ratio of hits to all shots, in all the games.
+	Accuracy float64 `json:"accuracy"`
+	// Number of won games by the shots needed to win, grouped in buckets.
+	Distribution []Bucket `json:"distribution"`
+}
+
+// Number of games won with the number of shots in range [From, To].
+type Bucket struct {
+	From  int `json:"from"`
+	To    int `json:"to"`
+	Count int `json:"count"`
+}
+
+// Checks that the configuration names existing strategies.
+func (c Config) Validate() error {
+	if c.Games <= 0 {
+		return fmt.Errorf("number of games must be positive")
+	}
+	for _, p := range c.Players {
+		if _, err := ai.NewStrategy(p.Strategy, nil); err != nil {
+			return err
+		}
+		if _, err := ai.NewPlacement(p.Placement, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Plays all the games of the configuration and returns the statistics of both players.
+func Run(cfg Config) (Report, error) {
+	if err := cfg.Validate(); err != nil {
+		return Report{}, err
+	}
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	results := make([]gameResult, cfg.Games)
+	errs := make([]error, cfg.Games)
+	games := make(chan int)
+	wg := sync.WaitGroup{}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range games {
+				results[i], errs[i] = runGame(cfg, i)
+			}
+		}()
+	}
+	for i := 0; i < cfg.Games; i++ {
+		games <- i
+	}
+	close(games)
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return Report{}, fmt.Errorf("game %d failed: %w", i+1, err)
+		}
+	}
+	return newReport(cfg, results), nil
+}
+
+// Plays the game with the given index. Every game has its own generator, so the result does not depend on the order of games.
+func runGame(cfg Config, index int) (gameResult, error) {
+	rng := rand.New(rand.NewSource(cfg.Seed + int64(index)))
+	sides := [2]*side{}
+	for i, p := range cfg.Players {
+		strategy, err := ai.NewStrategy(p.Strategy, rng)
+		if err != nil {
+			return gameResult{}, err
+		}
+		placement, err := ai.NewPlacement(p.Placement, rng)
+		if err != nil {
+			return gameResult{}, err
+		}
+		sides[i] = &side{strategy: strategy, ships: placement.Place()}
+	}
+	return playGame(sides, index%2)
+}
+
+func newReport(cfg Config, results []gameResult) Report {
+	report := Report{Games: len(results), Seed: cfg.Seed}
+	turns := 0
+	for _, r := range results {
+		turns += r.turns
+	}
+	report.MeanTurns = float64(turns) / float64(len(results))
+
+	for i, p := range cfg.Players {
+		st := PlayerStats{Player: p}
+		wins := make([]int, 0)
+		shots, hits := 0, 0
+		for _, r := range results {
+			shots += r.shots[i]
+			hits += r.hits[i]
+			if r.winner == i {
+				wins = append(wins, r.shots[i])
+			}
+		}
+		st.Wins = len(wins)
+		st.WinRate = float64(st.Wins) / float64(len(results))
+		if shots > 0 {
+			st.Accuracy = float64(hits) / float64(shots)
+		}
+		if len(wins) > 0 {
+			sort.Ints(wins)
+			sum := 0
+			for _, s := range wins {
+				sum += s
+			}
+			st.MeanShotsToWin = float64(sum) / float64(len(wins))
+			st.MedianShotsToWin = median(wins)
+			st.MinShotsToWin = wins[0]
+			st.MaxShotsToWin = wins[len(wins)-1]
+		}
+		st.Distribution = distribution(wins)
+		report.Players[i] = st
+	}
+	return report
+}
+
+// Returns the median of the sorted values.
+func median(sorted []int) float64 {
+	n := len(sorted)
+	if n%2 == 1 {
+		return float64(sorted[n/2])
+	}
+	return float64(sorted[n/2-1]+sorted[n/2]) / 2
+}
+
+// Groups the sorted values into buckets of `bucketSize`. Empty buckets between the smallest and the largest value are included.
+func distribution(sorted []int) []Bucket {
+	buckets := make([]Bucket, 0)
+	if len(sorted) == 0 {
+		return buckets
+	}
+	first := sorted[0] / bucketSize * bucketSize
+	last := sorted[len(sorted)-1] / bucketSize * bucketSize
+	for from := first; from <= last; from += bucketSize {
+		buckets = append(buckets, Bucket{From: from, To: from + bucketSize - 1})
+	}
+	for _, v := range sorted {
+		buckets[(v-first)/bucketSize].Count++
+	}
+	return buckets
+}
@@ -0,0 +1,65 @@
+package sim
+
+import (
+	"battleship_client/ai"
+	"reflect"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	cfg := Config{
+		Players: [2]Player{
+			{Strategy: ai.DensityStrategy, Placement: ai.RandomPlacement},
+			{Strategy: ai.RandomStrategy, Placement: ai.EdgePlacement},
+		},
+		Games:   20,
+		Seed:    7,
+		Workers: 4,
+	}
+	report, err := Run(cfg)
+	if err != nil {
+		t.Fatalf("Run() error: %s", err)
+	}
+	if report.Games != cfg.Games {
+		t.Errorf("games = %d, want %d", report.Games, cfg.Games)
+	}
+	if wins := report.Players[0].Wins + report.Players[1].Wins; wins != cfg.Games {
+		t.Errorf("wins = %d, want %d", wins, cfg.Games)
+	}
+	for i, p := range report.Players {
+		if p.Wins > 0 && (p.MinShotsToWin < 20 || p.MaxShotsToWin > 100) {
+			t.Errorf("player %d won with %d to %d shots, want from 20 to 100", i, p.MinShotsToWin, p.MaxShotsToWin)
+		}
+	}
+
+	// The same seed gives the same report regardless of the number of workers.
+	cfg.Workers = 1
+	again, err := Run(cfg)
+	if err != nil {
+		t.Fatalf("Run() error: %s", err)
+	}
+	if !reflect.DeepEqual(report, again) {
+		t.Errorf("reports of the same seed differ:\n%+v\n%+v", report, again)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	valid := Player{Strategy: ai.HuntStrategy, Placement: ai.RandomPlacement}
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{"valid", Config{Players: [2]Player{valid, valid}, Games: 1}, false},
+		{"no games", Config{Players: [2]Player{valid, valid}}, true},
+		{"unknown strategy", Config{Players: [2]Player{valid, {Strategy: "sniper", Placement: ai.RandomPlacement}}, Games: 1}, true},
+		{"unknown placement", Config{Players: [2]Player{{Strategy: ai.HuntStrategy, Placement: "corner"}, valid}, Games: 1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
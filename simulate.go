@@ -0,0 +1,37 @@
+package main
+
+import (
+	"battleship_client/ai"
+	"battleship_client/sim"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Plays games between two strategies without the GUI and prints the statistics.
+func simulate(args []string) error {
+	cfg := sim.Config{}
+	fs := flag.NewFlagSet("simulate", flag.ExitOnError)
+	strategies := strings.Join(ai.StrategyNames(), ", ")
+	placements := strings.Join(ai.PlacementNames(), ", ")
+	fs.StringVar(&cfg.Players[0].Strategy, "a", ai.DensityStrategy, "targeting strategy of the first player: "+strategies)
+	fs.StringVar(&cfg.Players[1].Strategy, "b", ai.HuntStrategy, "targeting strategy of the second player: "+strategies)
+	fs.StringVar(&cfg.Players[0].Placement, "place-a", ai.RandomPlacement, "placement strategy of the first player: "+placements)
+	fs.StringVar(&cfg.Players[1].Placement, "place-b", ai.RandomPlacement, "placement strategy of the second player: "+placements)
+	fs.IntVar(&cfg.Games, "n", 1000, "number of games to play")
+	fs.Int64Var(&cfg.Seed, "seed", time.Now().UnixNano(), "seed of the random generators")
+	fs.IntVar(&cfg.Workers, "workers", 0, "number of games played at the same time, 0 for the number of CPUs")
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	fs.Parse(args)
+
+	report, err := sim.Run(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to simulate: %w", err)
+	}
+	if *asJSON {
+		return report.WriteJSON(os.Stdout)
+	}
+	return report.WriteText(os.Stdout)
+}
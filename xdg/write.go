@@ -0,0 +1,24 @@
+package xdg
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Writes the data to the file, creating its directory if needed.
+// The data is written to a temporary file that then replaces the file at once, so it is never left half-written.
+func WriteFile(path string, data []byte, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, perm); err != nil {
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to replace file: %w", err)
+	}
+	return nil
+}
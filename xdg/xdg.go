@@ -0,0 +1,43 @@
+// Package xdg returns the directories where the client keeps its files, following the XDG Base Directory specification.
+package xdg
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Name of the subdirectory created for the client in every base directory.
+const appName = "battleship_client"
+
+// Returns the directory for configuration files, e.g. ~/.config/battleship_client.
+func ConfigDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find config directory: %w", err)
+	}
+	return filepath.Join(dir, appName), nil
+}
+
+// Returns the directory for data files, e.g. ~/.local/share/battleship_client.
+func DataDir() (string, error) {
+	return baseDir("XDG_DATA_HOME", ".local/share")
+}
+
+// Returns the directory for state that should survive restarts, e.g. ~/.local/state/battleship_client.
+func StateDir() (string, error) {
+	return baseDir("XDG_STATE_HOME", ".local/state")
+}
+
+// Returns the app subdirectory of the directory from the environment variable,
+// or of the default directory relative to the home directory if the variable is not set.
+func baseDir(env string, homeDefault string) (string, error) {
+	if dir := os.Getenv(env); dir != "" {
+		return filepath.Join(dir, appName), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find home directory: %w", err)
+	}
+	return filepath.Join(home, homeDefault, appName), nil
+}